@@ -52,6 +52,17 @@ func Authenticate(appDataDir string) {
 	}
 }
 
+// Connect authenticates with a saved token, without asking the user to log
+// in, and reports whether it could. Commands use it so they never block on
+// a login prompt.
+func Connect(appDataDir string) bool {
+	if _, err := tokenFromFile(filepath.Join(appDataDir, "token.json")); err != nil {
+		return false
+	}
+	Authenticate(appDataDir)
+	return true
+}
+
 // ... (getClient and other helper functions remain the same) ...
 func getClient(config *oauth2.Config, appDataDir string) *http.Client {
 	tokFile := filepath.Join(appDataDir, "token.json")
@@ -97,13 +108,24 @@ func saveToken(path string, token *oauth2.Token) {
 	defer f.Close()
 	json.NewEncoder(f).Encode(token)
 }
+
+// EventSummary is the title of a log's calendar event.
+func EventSummary(logEntry *model.Log) string {
+	return fmt.Sprintf("CP: %s (%s)", logEntry.QuestionID, logEntry.Platform)
+}
+
+// EventDescription is the body of a log's calendar event.
+func EventDescription(logEntry *model.Log) string {
+	return fmt.Sprintf("Topic: %s\nDifficulty: %s\nStatus: %s\nTime Spent: %d mins\n\nNotes:\n%s", logEntry.Topic, logEntry.Difficulty, logEntry.EffectiveStatus(), logEntry.TimeSpent, logEntry.Notes)
+}
+
 func AddLogToCalendar(logEntry *model.Log) (string, error) {
 	if calSrv == nil {
 		return "", fmt.Errorf("calendar service not initialized")
 	}
 	event := &calendar.Event{
-		Summary:     fmt.Sprintf("CP: %s (%s)", logEntry.QuestionID, logEntry.Platform),
-		Description: fmt.Sprintf("Topic: %s\nDifficulty: %s\nTime Spent: %d mins\n\nNotes:\n%s", logEntry.Topic, logEntry.Difficulty, logEntry.TimeSpent, logEntry.Notes),
+		Summary:     EventSummary(logEntry),
+		Description: EventDescription(logEntry),
 		Start:       &calendar.EventDateTime{Date: logEntry.Date.Format("2006-01-02")},
 		End:         &calendar.EventDateTime{Date: logEntry.Date.Format("2006-01-02")},
 	}
@@ -121,7 +143,8 @@ func DeleteCalendarEvent(eventID string) error {
 }
 
 // UPDATED: This function now correctly encodes the subject line.
-func SendReminderEmail() error {
+// Any non-empty status is appended to the randomized body, e.g. goal progress.
+func SendReminderEmail(status string) error {
 	if gmailSrv == nil {
 		return fmt.Errorf("gmail service not initialized")
 	}
@@ -145,6 +168,9 @@ func SendReminderEmail() error {
 
 	subject := livelySubjects[rng.Intn(len(livelySubjects))]
 	body := livelyBodies[rng.Intn(len(livelyBodies))]
+	if status != "" {
+		body += "\n\n" + status
+	}
 
 	// CORRECTED: Use mime.BEncoding to properly encode the subject.
 	encodedSubject := mime.BEncoding.Encode("UTF-8", subject)
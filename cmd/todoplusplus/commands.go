@@ -0,0 +1,800 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Harschmann/Todo-/calendar"
+	"github.com/Harschmann/Todo-/config"
+	"github.com/Harschmann/Todo-/core"
+	"github.com/Harschmann/Todo-/db"
+	"github.com/Harschmann/Todo-/export"
+	"github.com/Harschmann/Todo-/importer"
+	"github.com/Harschmann/Todo-/model"
+	"github.com/Harschmann/Todo-/query"
+	"github.com/Harschmann/Todo-/quickadd"
+	"github.com/Harschmann/Todo-/utils"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// commands maps subcommand names (e.g. `todoplusplus goal list`) to their handlers.
+var commands = map[string]func(args []string) error{
+	"goal":     runGoal,
+	"review":   runReview,
+	"problem":  runProblem,
+	"upsolve":  runUpsolve,
+	"contest":  runContest,
+	"import":   runImport,
+	"vault":    runVault,
+	"solution": runSolution,
+	"search":   runSearch,
+	"list":     runList,
+	"trash":    runTrash,
+	"history":  runHistory,
+	"undo":     runUndo,
+	"redate":   runRedate,
+	"add":      runAdd,
+	"dedupe":   runDedupe,
+}
+
+func runCommand(args []string) error {
+	run, ok := commands[args[0]]
+	if !ok {
+		names := make([]string, 0, len(commands))
+		for name := range commands {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		return fmt.Errorf("unknown command %q (available: %s)", args[0], strings.Join(names, ", "))
+	}
+	return run(args[1:])
+}
+
+func runGoal(args []string) error {
+	if len(args) == 0 {
+		args = []string{"list"}
+	}
+	switch args[0] {
+	case "add":
+		fs := flag.NewFlagSet("goal add", flag.ExitOnError)
+		metric := fs.String("metric", model.MetricProblems, "What to count: problems or minutes.")
+		period := fs.String("period", model.PeriodDay, "Goal period: day, week or month.")
+		target := fs.Int("target", 1, "How many problems or minutes to reach per period.")
+		topic := fs.String("topic", "", "Only count logs with this topic.")
+		platform := fs.String("platform", "", "Only count logs from this platform.")
+		fs.Parse(args[1:])
+		g := model.Goal{Metric: *metric, Period: *period, Target: *target, Topic: *topic, Platform: *platform}
+		if err := db.SaveGoal(&g); err != nil {
+			return err
+		}
+		fmt.Printf("Added goal #%d: %s\n", g.ID, g)
+	case "list":
+		progress, err := db.GetGoalProgress()
+		if err != nil {
+			return err
+		}
+		if len(progress) == 0 {
+			fmt.Println("No goals yet. Add one with: todoplusplus goal add -target 3 -period day")
+			return nil
+		}
+		for _, p := range progress {
+			fmt.Printf("#%-3d %-40s %d/%d (%.0f%%)\n", p.Goal.ID, p.Goal, p.Current, p.Goal.Target, p.Percent()*100)
+		}
+	case "remove":
+		id, err := parseID(args, "goal remove")
+		if err != nil {
+			return err
+		}
+		if err := db.DeleteGoal(id); err != nil {
+			return err
+		}
+		fmt.Printf("Removed goal #%d\n", id)
+	default:
+		return fmt.Errorf("unknown goal command %q (want add, list or remove)", args[0])
+	}
+	return nil
+}
+
+func runReview(args []string) error {
+	fs := flag.NewFlagSet("review", flag.ExitOnError)
+	all := fs.Bool("all", false, "List every problem in the review queue, not just today's.")
+	fs.Parse(args)
+
+	now := time.Now()
+	var reviews []model.Review
+	var err error
+	if *all {
+		reviews, err = db.GetReviewQueue()
+	} else {
+		reviews, err = db.GetDueReviews(now)
+	}
+	if err != nil {
+		return err
+	}
+	if len(reviews) == 0 {
+		fmt.Println("Nothing due for review today. 🎉")
+		return nil
+	}
+	for _, r := range reviews {
+		fmt.Printf("%s  %-12s %-20s %-16s every %d days\n", r.Due.Format("2006-01-02"), r.Platform, r.QuestionID, r.Topic, r.Interval)
+	}
+	return nil
+}
+
+func runProblem(args []string) error {
+	if len(args) == 0 {
+		args = []string{"list"}
+	}
+	switch args[0] {
+	case "list":
+		stats, err := db.GetProblemStats()
+		if err != nil {
+			return err
+		}
+		printProblemStats(stats)
+	case "edit":
+		fs := flag.NewFlagSet("problem edit", flag.ExitOnError)
+		platform := fs.String("platform", "", "Platform of the problem to edit (required).")
+		id := fs.String("id", "", "Question ID of the problem to edit (required).")
+		title := fs.String("title", "", "New title.")
+		url := fs.String("url", "", "New URL.")
+		rating := fs.Int("rating", 0, "New difficulty rating, e.g. 1600.")
+		tags := fs.String("tags", "", "Comma-separated tags, e.g. dp,greedy.")
+		fs.Parse(args[1:])
+		if *platform == "" || *id == "" {
+			return fmt.Errorf("usage: todoplusplus problem edit -platform <platform> -id <question id> [-title ...] [-url ...] [-rating ...] [-tags ...]")
+		}
+		problem, err := db.GetProblem(model.Log{Platform: *platform, QuestionID: *id}.ProblemKey())
+		if err != nil {
+			return err
+		}
+		if *title != "" {
+			problem.Title = *title
+		}
+		if *url != "" {
+			problem.URL = *url
+		}
+		if *rating != 0 {
+			problem.Rating = *rating
+		}
+		if *tags != "" {
+			problem.Tags = strings.Split(*tags, ",")
+		}
+		if err := db.SaveProblem(&problem); err != nil {
+			return err
+		}
+		fmt.Printf("Updated %s %s\n", problem.Platform, problem.ProblemID)
+	default:
+		return fmt.Errorf("unknown problem command %q (want list or edit)", args[0])
+	}
+	return nil
+}
+
+func printProblemStats(stats []db.ProblemStats) {
+	for _, s := range stats {
+		fmt.Printf("%-12s %-20s %2d attempts  %-9s %4d mins  %s\n",
+			s.Problem.Platform, s.Problem.ProblemID, len(s.Attempts), s.Outcome(), s.TotalTime, s.Problem.Title)
+	}
+}
+
+func runUpsolve(args []string) error {
+	backlog, err := db.GetUpsolveBacklog()
+	if err != nil {
+		return err
+	}
+	if len(backlog) == 0 {
+		fmt.Println("Upsolve backlog is empty.")
+		return nil
+	}
+	for _, s := range backlog {
+		last := s.Attempts[len(s.Attempts)-1]
+		fmt.Printf("%s  %-12s %-20s %-10s %-16s %s\n", last.Date.Format("2006-01-02"), s.Problem.Platform, s.Problem.ProblemID, last.EffectiveStatus(), last.Topic, s.Problem.Title)
+	}
+	return nil
+}
+
+func runContest(args []string) error {
+	if len(args) == 0 {
+		args = []string{"list"}
+	}
+	switch args[0] {
+	case "add":
+		fs := flag.NewFlagSet("contest add", flag.ExitOnError)
+		platform := fs.String("platform", "", "Platform the contest was held on (required).")
+		name := fs.String("name", "", "Contest name, e.g. \"Codeforces Round 900 (Div. 2)\" (required).")
+		date := fs.String("date", time.Now().Format("2006-01-02"), "Contest date, YYYY-MM-DD or YYYY-MM-DD HH:MM.")
+		duration := fs.Int("duration", 120, "Contest duration in minutes.")
+		rank := fs.Int("rank", 0, "Final rank.")
+		delta := fs.Int("delta", 0, "Rating change.")
+		rating := fs.Int("rating", 0, "Rating after the contest, if known.")
+		fs.Parse(args[1:])
+		when, err := utils.ParseDate(*date)
+		if err != nil {
+			return err
+		}
+		c := model.Contest{Platform: *platform, Name: *name, Date: when, Duration: *duration, Rank: *rank, RatingDelta: *delta, NewRating: *rating}
+		if err := db.SaveContest(&c); err != nil {
+			return err
+		}
+		fmt.Printf("Added contest #%d: %s\n", c.ID, c.Name)
+	case "list":
+		contests, err := db.GetAllContests()
+		if err != nil {
+			return err
+		}
+		for _, c := range contests {
+			fmt.Printf("#%-3d %s  %-12s %-40s rank %-6d %+d\n", c.ID, c.Date.Format("2006-01-02"), c.Platform, c.Name, c.Rank, c.RatingDelta)
+		}
+	case "show":
+		id, err := parseID(args, "contest show")
+		if err != nil {
+			return err
+		}
+		c, err := db.GetContest(id)
+		if err != nil {
+			return err
+		}
+		logs, err := db.GetContestLogs(id)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("%s (%s) on %s, %d mins\nRank %d, rating %+d\n\n", c.Name, c.Platform, c.Date.Format("2006-01-02 15:04"), c.Duration, c.Rank, c.RatingDelta)
+		for _, l := range logs {
+			fmt.Printf("  %-20s %-10s %-16s %3d mins\n", l.QuestionID, l.EffectiveStatus(), l.Topic, l.TimeSpent)
+		}
+	case "stats":
+		summaries, err := db.GetContestSummaries()
+		if err != nil {
+			return err
+		}
+		for _, s := range summaries {
+			fmt.Printf("%s: %d contests, best rank %d, %d problems solved, total %+d\n", s.Platform, s.Contests, s.BestRank, s.Solved, s.TotalDelta)
+			for _, p := range s.History {
+				rating := "?"
+				if p.Known {
+					rating = strconv.Itoa(p.Rating)
+				}
+				fmt.Printf("  %s  %5s  %+5d  %s\n", p.Contest.Date.Format("2006-01-02"), rating, p.Contest.RatingDelta, p.Contest.Name)
+			}
+		}
+	case "remove":
+		id, err := parseID(args, "contest remove")
+		if err != nil {
+			return err
+		}
+		if err := db.DeleteContest(id); err != nil {
+			return err
+		}
+		fmt.Printf("Removed contest #%d\n", id)
+	default:
+		return fmt.Errorf("unknown contest command %q (want add, list, show, stats or remove)", args[0])
+	}
+	return nil
+}
+
+func runSolution(args []string) error {
+	if len(args) == 0 {
+		return fmt.Errorf("usage: todoplusplus solution <add|list|show|remove> ...")
+	}
+	switch args[0] {
+	case "add":
+		fs := flag.NewFlagSet("solution add", flag.ExitOnError)
+		platform := fs.String("platform", "", "Platform of the problem (required).")
+		id := fs.String("id", "", "Question ID of the problem (required).")
+		date := fs.String("date", "", "Attach to the attempt on this day (YYYY-MM-DD) instead of the latest one.")
+		lang := fs.String("lang", "", "Language, e.g. cpp or python. Detected from the file name if unset.")
+		fs.Parse(args[1:])
+		if *platform == "" || *id == "" || fs.NArg() == 0 {
+			return fmt.Errorf("usage: todoplusplus solution add -platform <platform> -id <question id> [-date ...] [-lang ...] <file>...")
+		}
+		attempt, err := findAttempt(*platform, *id, *date)
+		if err != nil {
+			return err
+		}
+		for _, path := range fs.Args() {
+			code, err := os.ReadFile(path)
+			if err != nil {
+				return err
+			}
+			language := *lang
+			if language == "" {
+				language = utils.LanguageForFile(path)
+			}
+			s := model.Solution{LogDate: attempt.Date, Language: language, FileName: filepath.Base(path), Code: string(code)}
+			if err := db.SaveSolution(&s); err != nil {
+				return err
+			}
+			fmt.Printf("Attached solution #%d (%s) to %s %s from %s\n", s.ID, s.FileName, attempt.Platform, attempt.QuestionID, attempt.Date.Format("2006-01-02 15:04"))
+		}
+	case "list":
+		fs := flag.NewFlagSet("solution list", flag.ExitOnError)
+		platform := fs.String("platform", "", "Platform of the problem (required).")
+		id := fs.String("id", "", "Question ID of the problem (required).")
+		fs.Parse(args[1:])
+		if *platform == "" || *id == "" {
+			return fmt.Errorf("usage: todoplusplus solution list -platform <platform> -id <question id>")
+		}
+		logs, err := db.GetAllLogs()
+		if err != nil {
+			return err
+		}
+		key := model.Log{Platform: *platform, QuestionID: *id}.ProblemKey()
+		for _, l := range logs {
+			if l.ProblemKey() != key {
+				continue
+			}
+			solutions, err := db.GetSolutions(l.Date)
+			if err != nil {
+				return err
+			}
+			for _, s := range solutions {
+				fmt.Printf("#%-3d %s  %-10s %-12s %s\n", s.ID, l.Date.Format("2006-01-02 15:04"), l.EffectiveStatus(), s.Language, s.FileName)
+			}
+		}
+	case "show":
+		id, err := parseID(args, "solution show")
+		if err != nil {
+			return err
+		}
+		s, err := db.GetSolution(id)
+		if err != nil {
+			return err
+		}
+		fmt.Print(s.Code)
+	case "remove":
+		id, err := parseID(args, "solution remove")
+		if err != nil {
+			return err
+		}
+		if err := db.DeleteSolution(id); err != nil {
+			return err
+		}
+		fmt.Printf("Removed solution #%d\n", id)
+	default:
+		return fmt.Errorf("unknown solution command %q (want add, list, show or remove)", args[0])
+	}
+	return nil
+}
+
+// findAttempt returns the latest log of a problem, or the one on day if set.
+func findAttempt(platform, questionID, day string) (model.Log, error) {
+	logs, err := db.GetAllLogs()
+	if err != nil {
+		return model.Log{}, err
+	}
+	var from, to time.Time
+	if day != "" {
+		if from, err = utils.ParseDate(day); err != nil {
+			return model.Log{}, err
+		}
+		to = from.AddDate(0, 0, 1)
+	}
+	key := model.Log{Platform: platform, QuestionID: questionID}.ProblemKey()
+	var found *model.Log
+	for i, l := range logs {
+		if l.ProblemKey() != key || (day != "" && (l.Date.Before(from) || !l.Date.Before(to))) {
+			continue
+		}
+		if found == nil || l.Date.After(found.Date) {
+			found = &logs[i]
+		}
+	}
+	if found == nil {
+		return model.Log{}, fmt.Errorf("no log of %s %s found", platform, questionID)
+	}
+	return *found, nil
+}
+
+func runList(args []string) error {
+	fs := flag.NewFlagSet("list", flag.ExitOnError)
+	limit := fs.Int("n", 0, "Only show the most recent n logs.")
+	fs.Usage = func() {
+		fmt.Fprintf(fs.Output(), "usage: todoplusplus list [-n 20] [query...]\n\nQuery fields: %s, e.g. topic:dp difficulty:hard date>=2026-01-01 time>60\n", strings.Join(query.Fields(), ", "))
+		fs.PrintDefaults()
+	}
+	fs.Parse(args)
+	q, err := query.Parse(strings.Join(fs.Args(), " "))
+	if err != nil {
+		return err
+	}
+	logs, err := db.GetAllLogs()
+	if err != nil {
+		return err
+	}
+	logs = q.Filter(logs)
+	if *limit > 0 && len(logs) > *limit {
+		logs = logs[len(logs)-*limit:]
+	}
+	total := 0
+	for _, l := range logs {
+		fmt.Printf("%s  %-12s %-20s %-16s %-10s %-10s %4d mins\n", l.Date.Format("2006-01-02 15:04"), l.Platform, l.QuestionID, l.Topic, l.Difficulty, l.EffectiveStatus(), l.TimeSpent)
+		total += l.TimeSpent
+	}
+	fmt.Printf("%d logs, %d mins\n", len(logs), total)
+	return nil
+}
+
+var matchStyle = lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("170"))
+
+func runSearch(args []string) error {
+	fs := flag.NewFlagSet("search", flag.ExitOnError)
+	limit := fs.Int("n", 20, "Show at most this many results.")
+	fs.Parse(args)
+	query := strings.Join(fs.Args(), " ")
+	if strings.TrimSpace(query) == "" {
+		return fmt.Errorf("usage: todoplusplus search [-n 20] <words...>")
+	}
+
+	results, err := db.Search(query)
+	if err != nil {
+		return err
+	}
+	if len(results) == 0 {
+		fmt.Printf("No notes or solutions mention %q.\n", query)
+		return nil
+	}
+	terms := db.Terms(query)
+	for i, r := range results {
+		if i == *limit {
+			fmt.Printf("... and %d more\n", len(results)-i)
+			break
+		}
+		l := r.Log
+		fmt.Printf("%s  %-12s %-20s %s\n", l.Date.Format("2006-01-02"), l.Platform, l.QuestionID, r.Source)
+		fmt.Printf("    %s\n", db.HighlightTerms(r.Snippet, terms, func(s string) string { return matchStyle.Render(s) }))
+	}
+	return nil
+}
+
+func runTrash(args []string) error {
+	if len(args) == 0 {
+		return fmt.Errorf("usage: todoplusplus trash <list|restore|purge> ...")
+	}
+	switch args[0] {
+	case "list":
+		trash, err := db.GetTrash()
+		if err != nil {
+			return err
+		}
+		if len(trash) == 0 {
+			fmt.Println("The trash is empty.")
+			return nil
+		}
+		for _, t := range trash {
+			l := t.Log
+			fmt.Printf("#%-3d deleted %s  %s  %-12s %-20s %d solutions\n", t.ID, t.Deleted.Format("2006-01-02 15:04"), l.Date.Format("2006-01-02 15:04"), l.Platform, l.QuestionID, len(t.Solutions))
+		}
+	case "restore":
+		id, err := parseID(args, "trash restore")
+		if err != nil {
+			return err
+		}
+		connected := connectCalendar()
+		restored, err := core.RestoreLog(id)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("Restored %s %s from %s\n", restored.Platform, restored.QuestionID, restored.Date.Format("2006-01-02 15:04"))
+		if !connected {
+			fmt.Println(notConnected)
+		}
+	case "purge":
+		fs := flag.NewFlagSet("trash purge", flag.ExitOnError)
+		days := fs.Int("days", 0, "Only purge logs deleted more than this many days ago.")
+		fs.Parse(args[1:])
+		n, err := db.PurgeTrash(time.Now().AddDate(0, 0, -*days))
+		if err != nil {
+			return err
+		}
+		fmt.Printf("Permanently deleted %d logs\n", n)
+	default:
+		return fmt.Errorf("unknown trash command %q (want list, restore or purge)", args[0])
+	}
+	return nil
+}
+
+func runHistory(args []string) error {
+	if len(args) == 0 {
+		return fmt.Errorf("usage: todoplusplus history <show|revert> ...")
+	}
+	switch args[0] {
+	case "show":
+		fs := flag.NewFlagSet("history show", flag.ExitOnError)
+		platform := fs.String("platform", "", "Platform of the problem (required).")
+		id := fs.String("id", "", "Question ID of the problem (required).")
+		date := fs.String("date", "", "Show the attempt on this day (YYYY-MM-DD) instead of the latest one.")
+		fs.Parse(args[1:])
+		if *platform == "" || *id == "" {
+			return fmt.Errorf("usage: todoplusplus history show -platform <platform> -id <question id> [-date ...]")
+		}
+		attempt, err := findAttempt(*platform, *id, *date)
+		if err != nil {
+			return err
+		}
+		revisions, err := db.GetRevisions(attempt.Date)
+		if err != nil {
+			return err
+		}
+		if len(revisions) == 0 {
+			fmt.Println("This log has not been edited.")
+			return nil
+		}
+		for _, r := range revisions {
+			l := r.Log
+			fmt.Printf("#%-3d before %s: %s  %-16s %-10s %-10s %4d mins  %s\n", r.ID, r.Changed.Format("2006-01-02 15:04"), l.Date.Format("2006-01-02 15:04"), l.Topic, l.Difficulty, l.EffectiveStatus(), l.TimeSpent, strings.Join(strings.Fields(l.Notes), " "))
+		}
+	case "revert":
+		id, err := parseID(args, "history revert")
+		if err != nil {
+			return err
+		}
+		reverted, err := db.RevertLog(id)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("Reverted %s %s to revision #%d\n", reverted.Platform, reverted.QuestionID, id)
+	default:
+		return fmt.Errorf("unknown history command %q (want show or revert)", args[0])
+	}
+	return nil
+}
+
+func runUndo(args []string) error {
+	connected := connectCalendar()
+	status, err := core.Undo()
+	if err != nil {
+		return err
+	}
+	fmt.Println(status)
+	if !connected {
+		fmt.Println(notConnected)
+	}
+	return nil
+}
+
+func runAdd(args []string) error {
+	fs := flag.NewFlagSet("add", flag.ExitOnError)
+	dryRun := fs.Bool("dry-run", false, "Show the parsed log without saving it.")
+	merge := fs.Bool("merge", false, "If the problem was logged recently, merge into that log.")
+	again := fs.Bool("again", false, "If the problem was logged recently, add this as a re-attempt anyway.")
+	fs.Usage = func() {
+		fmt.Fprintf(fs.Output(), "usage: todoplusplus add [-dry-run] [-merge|-again] <platform> <question id> <topic> <difficulty> [status] [time] [@date] [\"notes\"]\n\n"+
+			"e.g. todoplusplus add cf 1337A dp hard 45m \"used prefix sums\"\n     todoplusplus add lc two-sum bs easy wa 20m @yesterday\n")
+		fs.PrintDefaults()
+	}
+	fs.Parse(args)
+	if fs.NArg() == 0 {
+		fs.Usage()
+		return fmt.Errorf("nothing to add")
+	}
+	l, err := quickadd.ParseWords(fs.Args())
+	if err != nil {
+		return err
+	}
+	dup, found := core.FindDuplicate(l)
+	if *again {
+		found = false
+	}
+	if *dryRun {
+		fmt.Printf("Would add %s\n", describeLog(l))
+		if found {
+			fmt.Printf("It looks like a duplicate of %s\n", describeLog(dup))
+		}
+		return nil
+	}
+	if found && !*merge {
+		return fmt.Errorf("%s %s was already logged on %s; add -merge to merge into that log or -again to add a re-attempt", l.Platform, l.QuestionID, dup.Date.Format("2006-01-02 15:04"))
+	}
+	connected := connectCalendar()
+	if found {
+		merged := core.Merge(dup, l)
+		if err := core.UpdateLogs([]model.Log{merged}); err != nil {
+			return err
+		}
+		fmt.Printf("Merged into %s\n", describeLog(merged))
+	} else {
+		if err := core.AddLog(&l); err != nil {
+			return err
+		}
+		fmt.Printf("Added %s\n", describeLog(l))
+	}
+	if !connected {
+		fmt.Println(notConnected)
+	}
+	return nil
+}
+
+func runDedupe(args []string) error {
+	fs := flag.NewFlagSet("dedupe", flag.ExitOnError)
+	window := fs.Int("window", config.Get().DuplicateWindowHours, "Logs of a problem within this many hours of each other are duplicates.")
+	apply := fs.Bool("apply", false, "Merge each group of duplicates into its first log. Without it, only list them.")
+	fs.Parse(args)
+	if *window <= 0 {
+		return fmt.Errorf("the window must be at least 1 hour")
+	}
+	groups, err := db.DuplicateGroups(time.Duration(*window) * time.Hour)
+	if err != nil {
+		return err
+	}
+	if len(groups) == 0 {
+		fmt.Println("No duplicate logs found.")
+		return nil
+	}
+	for _, group := range groups {
+		fmt.Printf("%s %s, %d logs:\n", group[0].Platform, group[0].QuestionID, len(group))
+		for _, l := range group {
+			fmt.Printf("  %s  %-10s %-16s %3d mins\n", l.Date.Format("2006-01-02 15:04"), l.EffectiveStatus(), l.Topic, l.TimeSpent)
+		}
+	}
+	if !*apply {
+		fmt.Println("\nRun with -apply to merge each group into its first log.")
+		return nil
+	}
+	connected := connectCalendar()
+	if _, err := core.MergeGroups(groups); err != nil {
+		return err
+	}
+	fmt.Printf("\nMerged %d groups. Undo with: todoplusplus undo\n", len(groups))
+	if !connected {
+		fmt.Println(notConnected)
+	}
+	return nil
+}
+
+// describeLog summarizes a log on one line.
+func describeLog(l model.Log) string {
+	date := "now"
+	if !l.Date.IsZero() {
+		date = l.Date.Format("2006-01-02 15:04")
+	}
+	s := fmt.Sprintf("%s %s (%s, %s, %s, %d mins) on %s", l.Platform, l.QuestionID, l.Topic, l.Difficulty, l.EffectiveStatus(), l.TimeSpent, date)
+	if l.Notes != "" {
+		s += ": " + strings.Join(strings.Fields(l.Notes), " ")
+	}
+	return s
+}
+
+func runRedate(args []string) error {
+	fs := flag.NewFlagSet("redate", flag.ExitOnError)
+	platform := fs.String("platform", "", "Platform of the problem (required).")
+	id := fs.String("id", "", "Question ID of the problem (required).")
+	date := fs.String("date", "", "Move the attempt on this day (YYYY-MM-DD) instead of the latest one.")
+	fs.Parse(args)
+	if *platform == "" || *id == "" || fs.NArg() == 0 {
+		return fmt.Errorf("usage: todoplusplus redate -platform <platform> -id <question id> [-date ...] <new date, e.g. yesterday 21:30 or -2d>")
+	}
+	when, err := utils.ParseLogDate(strings.Join(fs.Args(), " "))
+	if err != nil {
+		return err
+	}
+	attempt, err := findAttempt(*platform, *id, *date)
+	if err != nil {
+		return err
+	}
+	connected := connectCalendar()
+	moved, err := core.MoveLog(attempt, when)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("Moved %s %s from %s to %s\n", moved.Platform, moved.QuestionID, attempt.Date.Format("2006-01-02 15:04"), moved.Date.Format("2006-01-02 15:04"))
+	if !connected {
+		fmt.Println(notConnected)
+	}
+	return nil
+}
+
+const notConnected = "Not signed in to Google Calendar, so the calendar was not updated; launch the app once to sign in."
+
+// connectCalendar signs in to Google Calendar if the app has a saved login.
+func connectCalendar() bool {
+	appDataDir, err := db.GetAppDataDir()
+	return err == nil && calendar.Connect(appDataDir)
+}
+
+// parseID reads a numeric ID such as "3" or "#3" from args[1].
+func parseID(args []string, usage string) (int, error) {
+	if len(args) < 2 {
+		return 0, fmt.Errorf("usage: todoplusplus %s <id>", usage)
+	}
+	id, err := strconv.Atoi(strings.TrimPrefix(args[1], "#"))
+	if err != nil {
+		return 0, fmt.Errorf("invalid ID %q", args[1])
+	}
+	return id, nil
+}
+
+func runImport(args []string) error {
+	sources := []string{"csv", "xlsx"}
+	for name := range importer.Parsers {
+		sources = append(sources, name)
+	}
+	sort.Strings(sources)
+
+	fs := flag.NewFlagSet("import", flag.ExitOnError)
+	dryRun := fs.Bool("dry-run", false, "Print what would be imported without saving anything.")
+	mapSpec := fs.String("map", "", "csv/xlsx only: map log fields to column headers, e.g. \"question id=Problem,date=Solved On\".")
+	fs.Parse(args)
+	args = fs.Args()
+	if len(args) < 2 {
+		return fmt.Errorf("usage: todoplusplus import [-dry-run] [-map spec] <%s> <file>", strings.Join(sources, "|"))
+	}
+
+	source := strings.ToLower(args[0])
+	opts := importer.Options{DryRun: *dryRun}
+	parse, ok := importer.Parsers[source]
+	if source == "csv" || source == "xlsx" {
+		mapping, err := importer.ParseColumnMapping(*mapSpec)
+		if err != nil {
+			return err
+		}
+		if parse, err = importer.SpreadsheetParser(source, mapping); err != nil {
+			return err
+		}
+		opts.KeepAttempts = true
+	} else if !ok {
+		return fmt.Errorf("unknown import source %q (available: %s)", args[0], strings.Join(sources, ", "))
+	}
+
+	f, err := os.Open(args[1])
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	entries, err := parse(f)
+	if err != nil {
+		return err
+	}
+	result, err := importer.Import(entries, opts)
+	if err != nil {
+		return err
+	}
+	for _, e := range result.Invalid {
+		fmt.Printf("! skipped: %v\n", e.Err)
+	}
+	invalid := ""
+	if len(result.Invalid) > 0 {
+		invalid = fmt.Sprintf(", %d with invalid question IDs", len(result.Invalid))
+	}
+	if *dryRun {
+		for _, e := range result.Added {
+			l := e.Log
+			fmt.Printf("+ %s  %-12s %-24s %-16s %s\n", l.Date.Format("2006-01-02 15:04"), l.Platform, l.QuestionID, l.Topic, l.Difficulty)
+		}
+		fmt.Printf("Dry run: would import %d logs (%d skipped as already logged or duplicated%s).\n", len(result.Added), result.Skipped, invalid)
+		return nil
+	}
+	fmt.Printf("Imported %d logs (%d skipped as already logged or duplicated%s).\n", len(result.Added), result.Skipped, invalid)
+	return nil
+}
+
+func runVault(args []string) error {
+	fs := flag.NewFlagSet("vault", flag.ExitOnError)
+	perProblem := fs.Bool("per-problem", false, "Write one note per problem instead of one per log.")
+	fs.Parse(args)
+	if fs.NArg() != 1 {
+		return fmt.Errorf("usage: todoplusplus vault [-per-problem] <directory>")
+	}
+
+	logs, err := export.FilteredLogs(export.Filter{})
+	if err != nil {
+		return err
+	}
+	result, err := export.WriteVault(fs.Arg(0), logs, *perProblem)
+	if err != nil {
+		return err
+	}
+	for _, path := range result.Kept {
+		fmt.Printf("kept %s (edited by hand)\n", path)
+	}
+	for _, path := range result.Removed {
+		fmt.Printf("removed %s\n", path)
+	}
+	fmt.Printf("Wrote %d notes to %s (%d unchanged, %d kept, %d removed).\n", len(result.Written), fs.Arg(0), result.Unchanged, len(result.Kept), len(result.Removed))
+	return nil
+}
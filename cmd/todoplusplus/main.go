@@ -6,11 +6,16 @@ import (
 	"log"
 	"os"
 	"path/filepath"
+	"strings"
 
 	"github.com/Harschmann/Todo-/calendar"
+	"github.com/Harschmann/Todo-/config"
 	"github.com/Harschmann/Todo-/core"
 	"github.com/Harschmann/Todo-/db"
+	"github.com/Harschmann/Todo-/export"
+	"github.com/Harschmann/Todo-/query"
 	"github.com/Harschmann/Todo-/tui"
+	"github.com/Harschmann/Todo-/utils"
 	tea "github.com/charmbracelet/bubbletea"
 )
 
@@ -28,32 +33,75 @@ func main() {
 	}
 
 	reminderFlag := flag.Bool("reminder", false, "Send a reminder email if no log is present for today.")
-	exportFlag := flag.Bool("export", false, "Export all logs to an Excel file.")
+	exportFlag := flag.Bool("export", false, "Export all logs to a file.")
+	formatFlag := flag.String("format", "xlsx", "Export format: "+strings.Join(export.Formats(), ", ")+".")
+	outFlag := flag.String("out", "", "Export destination: a file, a directory, or - for stdout. Defaults to a timestamped file on the Desktop.")
+	fromFlag := flag.String("from", "", "Only export logs on or after this date (YYYY-MM-DD).")
+	toFlag := flag.String("to", "", "Only export logs on or before this date (YYYY-MM-DD).")
+	platformFlag := flag.String("platform", "", "Only export logs from this platform.")
+	topicFlag := flag.String("topic", "", "Only export logs with this topic.")
+	difficultyFlag := flag.String("difficulty", "", "Only export logs with this difficulty.")
+	queryFlag := flag.String("query", "", "Only export logs matching this query, e.g. \"topic:dp time>60\".")
 	flag.Parse()
 
 	setupLogging(filepath.Join(appDataDir, "app.log"))
+	if err := config.Load(appDataDir); err != nil {
+		// Logging goes to app.log by now, so show config mistakes here too.
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		log.Fatal(err)
+	}
 	if err := db.Init(filepath.Join(appDataDir, "tracker.db")); err != nil {
 		log.Fatal(err)
 	}
 
-	if *reminderFlag {
+	if flag.NArg() > 0 {
+		if err := runCommand(flag.Args()); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+
+	} else if *reminderFlag {
 		calendar.Authenticate(appDataDir)
 		log.Println("Running in reminder-only mode...")
 		core.CheckAndSendReminder()
 		log.Println("Reminder check complete.")
 
 	} else if *exportFlag {
-		fmt.Println("Exporting logs to Excel...")
-		fileName, err := db.ExportToExcel()
+		opts := export.Options{
+			Format: *formatFlag,
+			Output: *outFlag,
+			Filter: export.Filter{Platform: *platformFlag, Topic: *topicFlag, Difficulty: *difficultyFlag},
+		}
+		if err := parseDateRange(*fromFlag, *toFlag, &opts.Filter); err != nil {
+			fmt.Fprintf(os.Stderr, "Failed to export logs: %v\n", err)
+			os.Exit(1)
+		}
+		q, err := query.Parse(*queryFlag)
 		if err != nil {
-			log.Fatalf("Failed to export to Excel: %v", err)
+			fmt.Fprintf(os.Stderr, "Failed to export logs: %v\n", err)
+			os.Exit(1)
+		}
+		opts.Filter.Query = q
+		// Keep stdout clean for the export itself when writing there.
+		status := os.Stdout
+		if opts.Output == export.Stdout {
+			status = os.Stderr
+		}
+		fmt.Fprintf(status, "Exporting logs as %s...\n", opts.Format)
+		fileName, err := export.Run(opts)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Failed to export logs: %v\n", err)
+			os.Exit(1)
+		}
+		if fileName != export.Stdout {
+			fmt.Fprintf(status, "Successfully exported logs to %s\n", fileName)
 		}
-		fmt.Printf("Successfully exported logs to %s\n", fileName)
 
 	} else {
 		calendar.Authenticate(appDataDir)
 		go core.StartPeriodicBackups(appDataDir)
 
+		tui.DetectBackground()
 		initialModel := tui.NewForm()
 		p := tea.NewProgram(initialModel)
 		if _, err := p.Run(); err != nil {
@@ -98,6 +146,26 @@ func migrateData() error {
 	return nil
 }
 
+// parseDateRange fills in the filter's dates from --from and --to. The --to
+// day is inclusive.
+func parseDateRange(from, to string, filter *export.Filter) error {
+	if from != "" {
+		t, err := utils.ParseDate(from)
+		if err != nil {
+			return err
+		}
+		filter.From = t
+	}
+	if to != "" {
+		t, err := utils.ParseDate(to)
+		if err != nil {
+			return err
+		}
+		filter.To = t.AddDate(0, 0, 1)
+	}
+	return nil
+}
+
 func setupLogging(logPath string) {
 	f, err := os.OpenFile(logPath, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
 	if err != nil {
@@ -1 +1,130 @@
-package config
\ No newline at end of file
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/Harschmann/Todo-/model"
+)
+
+// Config holds user preferences read from config.json in the app data directory.
+type Config struct {
+	// CountedStatuses lists the log statuses that count as solved problems in
+	// stats, streaks and goals. Time spent always counts.
+	CountedStatuses []string `json:"counted_statuses"`
+	// SolutionLanguage is the language of solutions written in $EDITOR from
+	// the TUI, e.g. "cpp" or "python".
+	SolutionLanguage string `json:"solution_language"`
+	// LogLayout is how the TUI shows saved logs: "list" or "table". It and
+	// the sort below are remembered in state.json when changed in the TUI.
+	LogLayout string `json:"log_layout"`
+	// LogSort is the table column logs were last sorted by, e.g. "date" or
+	// "time", and LogSortDesc whether the order was descending.
+	LogSort     string `json:"log_sort"`
+	LogSortDesc bool   `json:"log_sort_desc"`
+	// PlatformAliases and TopicAliases add shorthands for quick add on top
+	// of the built-in ones, e.g. {"seg": "Data Structures"}.
+	PlatformAliases map[string]string `json:"platform_aliases"`
+	TopicAliases    map[string]string `json:"topic_aliases"`
+	// DuplicateWindowHours is how close together two logs of the same problem
+	// must be to count as likely duplicates. 0 turns the check off.
+	DuplicateWindowHours int `json:"duplicate_window_hours"`
+}
+
+const (
+	fileName      = "config.json"
+	stateFileName = "state.json"
+)
+
+var (
+	current = Default()
+	dir     string // Where Load looked for the files, and SaveState writes
+)
+
+// state is the part of Config the app remembers itself. It is kept in its
+// own file so that config.json is only ever written by the user.
+type state struct {
+	LogLayout   string `json:"log_layout"`
+	LogSort     string `json:"log_sort"`
+	LogSortDesc bool   `json:"log_sort_desc"`
+}
+
+func Default() Config {
+	return Config{
+		CountedStatuses:      []string{model.StatusAccepted},
+		SolutionLanguage:     "cpp",
+		LogLayout:            "list",
+		LogSort:              "date",
+		LogSortDesc:          true,
+		DuplicateWindowHours: 24,
+	}
+}
+
+// Load reads config.json from appDataDir, keeping defaults for anything unset,
+// and then the remembered state from state.json. Missing files are not an
+// error, but a counted status that is not a log status is.
+func Load(appDataDir string) error {
+	cfg := Default()
+	dir = appDataDir
+	for _, name := range []string{fileName, stateFileName} {
+		data, err := os.ReadFile(filepath.Join(dir, name))
+		if errors.Is(err, fs.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		if err := json.Unmarshal(data, &cfg); err != nil {
+			return fmt.Errorf("could not parse %s: %w", name, err)
+		}
+	}
+	for _, status := range cfg.CountedStatuses {
+		if !slices.ContainsFunc(model.Statuses, func(s string) bool { return strings.EqualFold(s, status) }) {
+			return fmt.Errorf("unknown status %q in counted_statuses in %s (want one of %s)", status, fileName, strings.Join(model.Statuses, ", "))
+		}
+	}
+	current = cfg
+	return nil
+}
+
+// SaveState makes cfg the current configuration and writes the preferences
+// the app remembers itself, such as the last sort, to state.json.
+func SaveState(cfg Config) error {
+	current = cfg
+	if dir == "" {
+		return fmt.Errorf("cannot save %s before the config was loaded", stateFileName)
+	}
+	data, err := json.MarshalIndent(state{LogLayout: cfg.LogLayout, LogSort: cfg.LogSort, LogSortDesc: cfg.LogSortDesc}, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(dir, stateFileName), append(data, '\n'), 0644)
+}
+
+// Get returns the loaded configuration, or the defaults if Load was never called.
+func Get() Config {
+	return current
+}
+
+// Counts reports whether logs with the given status count towards stats.
+// Statuses are compared case-insensitively.
+func (c Config) Counts(status string) bool {
+	for _, s := range c.CountedStatuses {
+		if strings.EqualFold(s, status) {
+			return true
+		}
+	}
+	return false
+}
+
+// DuplicateWindow returns DuplicateWindowHours as a duration.
+func (c Config) DuplicateWindow() time.Duration {
+	return time.Duration(c.DuplicateWindowHours) * time.Hour
+}
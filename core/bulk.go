@@ -0,0 +1,40 @@
+package core
+
+import (
+	"log"
+	"time"
+
+	"github.com/Harschmann/Todo-/calendar"
+	"github.com/Harschmann/Todo-/db"
+	"github.com/Harschmann/Todo-/model"
+)
+
+// DeleteLogs moves several logs to the trash in one transaction and then
+// removes their calendar events.
+func DeleteLogs(logs []model.Log) error {
+	dates := make([]time.Time, len(logs))
+	for i, l := range logs {
+		dates[i] = l.Date
+	}
+	if err := db.DeleteLogs(dates); err != nil {
+		return err
+	}
+	for _, l := range logs {
+		if l.CalendarEventID != "" {
+			if err := calendar.DeleteCalendarEvent(l.CalendarEventID); err != nil {
+				log.Printf("Could not delete calendar event (it may have been already deleted): %v", err)
+			}
+		}
+	}
+	return nil
+}
+
+// UpdateLogs saves changes to several logs in one transaction and then
+// replaces their calendar events so they match. Saving unchanged logs
+// re-syncs them to the calendar.
+func UpdateLogs(logs []model.Log) error {
+	if err := db.UpdateLogs(logs); err != nil {
+		return err
+	}
+	return syncCalendarEvents(logs)
+}
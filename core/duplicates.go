@@ -0,0 +1,102 @@
+package core
+
+import (
+	"log"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/Harschmann/Todo-/calendar"
+	"github.com/Harschmann/Todo-/config"
+	"github.com/Harschmann/Todo-/db"
+	"github.com/Harschmann/Todo-/model"
+)
+
+// Merge combines duplicate logs of one problem into a copy of into: the time
+// spent adds up, distinct notes are joined, and the status is the best one,
+// that of the latest log whose status counts as solved, or else of the
+// latest log. into keeps its date, calendar event and other details, filling
+// in any that are empty from the others.
+func Merge(into model.Log, others ...model.Log) model.Log {
+	merged := into
+	best := into
+	notes := []string{}
+	if n := strings.TrimSpace(into.Notes); n != "" {
+		notes = append(notes, n)
+	}
+	for _, other := range others {
+		merged.TimeSpent += other.TimeSpent
+		if n := strings.TrimSpace(other.Notes); n != "" && !slices.Contains(notes, n) {
+			notes = append(notes, n)
+		}
+		if better(other, best) {
+			best = other
+		}
+		if merged.Topic == "" {
+			merged.Topic = other.Topic
+		}
+		if merged.Difficulty == "" {
+			merged.Difficulty = other.Difficulty
+		}
+		if merged.ContestID == 0 {
+			merged.ContestID = other.ContestID
+		}
+	}
+	merged.Status = best.Status
+	merged.Notes = strings.Join(notes, "\n\n")
+	return merged
+}
+
+// better reports whether a's status beats b's when merging: a status that
+// counts as solved beats one that does not, and otherwise the later log wins.
+func better(a, b model.Log) bool {
+	cfg := config.Get()
+	aCounts, bCounts := cfg.Counts(a.EffectiveStatus()), cfg.Counts(b.EffectiveStatus())
+	if aCounts != bCounts {
+		return aCounts
+	}
+	return a.Date.After(b.Date)
+}
+
+// MergeGroups merges each group of duplicate logs into its first log in one
+// transaction, then removes the others' calendar events and updates the
+// kept log's. It returns the kept logs.
+func MergeGroups(groups [][]model.Log) ([]model.Log, error) {
+	merges := make([]db.Merge, len(groups))
+	for i, group := range groups {
+		merges[i].Kept = Merge(group[0], group[1:]...)
+		for _, other := range group[1:] {
+			merges[i].Removed = append(merges[i].Removed, other.Date)
+		}
+	}
+	if err := db.MergeLogs(merges); err != nil {
+		return nil, err
+	}
+	kept := make([]model.Log, len(merges))
+	for i, group := range groups {
+		for _, other := range group[1:] {
+			if other.CalendarEventID != "" {
+				if err := calendar.DeleteCalendarEvent(other.CalendarEventID); err != nil {
+					log.Printf("Could not delete calendar event (it may have been already deleted): %v", err)
+				}
+			}
+		}
+		kept[i] = merges[i].Kept
+	}
+	return kept, syncCalendarEvents(kept)
+}
+
+// FindDuplicate returns a log of the same problem as l logged within the
+// configured duplicate window, if there is one. Failures are logged and
+// treated as no duplicate, so they never stop a log being saved.
+func FindDuplicate(l model.Log) (model.Log, bool) {
+	if l.Date.IsZero() {
+		l.Date = time.Now()
+	}
+	dup, found, err := db.FindDuplicate(l, config.Get().DuplicateWindow())
+	if err != nil {
+		log.Printf("Could not check for duplicate logs: %v", err)
+		return model.Log{}, false
+	}
+	return dup, found
+}
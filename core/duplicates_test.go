@@ -0,0 +1,56 @@
+package core
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Harschmann/Todo-/model"
+)
+
+func TestMerge(t *testing.T) {
+	at := time.Date(2026, 3, 11, 20, 0, 0, 0, time.Local)
+	into := model.Log{Date: at, Platform: "Codeforces", QuestionID: "1337A", Topic: "DP", Status: model.StatusAttempted, TimeSpent: 30, Notes: "tried dp", CalendarEventID: "kept"}
+	later := model.Log{Date: at.Add(time.Hour), Platform: "Codeforces", QuestionID: "1337A", Difficulty: "1600", Status: model.StatusAccepted, TimeSpent: 20, Notes: "prefix sums", ContestID: 7, CalendarEventID: "dropped"}
+
+	merged := Merge(into, later)
+	if merged.TimeSpent != 50 {
+		t.Errorf("TimeSpent = %d, want 50", merged.TimeSpent)
+	}
+	if merged.Notes != "tried dp\n\nprefix sums" {
+		t.Errorf("Notes = %q, want both notes", merged.Notes)
+	}
+	if !merged.Date.Equal(at) || merged.CalendarEventID != "kept" || merged.Topic != "DP" {
+		t.Errorf("merged log did not keep into's date, event and topic: %+v", merged)
+	}
+	if merged.Difficulty != "1600" || merged.ContestID != 7 {
+		t.Errorf("merged log did not fill in the empty difficulty and contest: %+v", merged)
+	}
+
+	// Repeated notes are kept once.
+	if got := Merge(into, model.Log{Notes: " tried dp "}).Notes; got != "tried dp" {
+		t.Errorf("Notes = %q, want the note once", got)
+	}
+}
+
+func TestMergeStatus(t *testing.T) {
+	at := time.Date(2026, 3, 11, 20, 0, 0, 0, time.Local)
+	attempt := func(status string, hours int) model.Log {
+		return model.Log{Date: at.Add(time.Duration(hours) * time.Hour), Status: status}
+	}
+	tests := []struct {
+		name   string
+		into   model.Log
+		others []model.Log
+		want   string
+	}{
+		{"a solve beats a later attempt", attempt(model.StatusAccepted, 0), []model.Log{attempt(model.StatusAttempted, 1)}, model.StatusAccepted},
+		{"a later solve wins", attempt(model.StatusAttempted, 0), []model.Log{attempt(model.StatusAccepted, 1)}, model.StatusAccepted},
+		{"an empty status is a solve", attempt("", 0), []model.Log{attempt(model.StatusUpsolve, 1)}, ""},
+		{"otherwise the latest wins", attempt(model.StatusAttempted, 0), []model.Log{attempt(model.StatusUpsolve, 2), attempt(model.StatusEditorial, 1)}, model.StatusUpsolve},
+	}
+	for _, tt := range tests {
+		if got := Merge(tt.into, tt.others...).Status; got != tt.want {
+			t.Errorf("%s: status %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
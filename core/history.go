@@ -0,0 +1,131 @@
+package core
+
+import (
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/Harschmann/Todo-/calendar"
+	"github.com/Harschmann/Todo-/db"
+	"github.com/Harschmann/Todo-/model"
+)
+
+// replaceCalendarEvent swaps a log's calendar event for one matching its
+// current details, without saving the log. The new event is added before the
+// old one is deleted, so a failed add keeps the old event and its ID. The
+// calendar is best effort: failures are logged.
+func replaceCalendarEvent(logEntry *model.Log) {
+	eventID, err := calendar.AddLogToCalendar(logEntry)
+	if err != nil {
+		log.Printf("Could not add calendar event: %v", err)
+		return
+	}
+	if logEntry.CalendarEventID != "" {
+		if err := calendar.DeleteCalendarEvent(logEntry.CalendarEventID); err != nil {
+			log.Printf("Could not delete calendar event (it may have been already deleted): %v", err)
+		}
+	}
+	logEntry.CalendarEventID = eventID
+}
+
+// syncCalendarEvents replaces the calendar events of logs that are already
+// saved and then saves the new event IDs in one write, so a database left
+// holding IDs of deleted events is reported rather than only logged.
+func syncCalendarEvents(logs []model.Log) error {
+	for i := range logs {
+		replaceCalendarEvent(&logs[i])
+	}
+	if err := db.SetCalendarEventIDs(logs); err != nil {
+		return fmt.Errorf("could not save calendar event IDs: %w", err)
+	}
+	return nil
+}
+
+// SyncCalendar replaces a log's calendar event with one matching its current
+// details and saves the new event ID.
+func SyncCalendar(logEntry *model.Log) error {
+	synced := []model.Log{*logEntry}
+	err := syncCalendarEvents(synced)
+	*logEntry = synced[0]
+	return err
+}
+
+// MoveLog changes the date of a log, moving its calendar event too.
+func MoveLog(logEntry model.Log, date time.Time) (model.Log, error) {
+	moved := logEntry
+	moved.Date = date
+	if err := db.UpdateLogAt(logEntry.Date, &moved); err != nil {
+		return logEntry, err
+	}
+	return moved, SyncCalendar(&moved)
+}
+
+// RestoreLog brings a log back from the trash and recreates its calendar
+// event, which was removed when the log was deleted.
+func RestoreLog(trashID int) (model.Log, error) {
+	restored, err := restoreLogs([]int{trashID})
+	if err != nil {
+		return model.Log{}, err
+	}
+	return restored[0], nil
+}
+
+func restoreLogs(trashIDs []int) ([]model.Log, error) {
+	restored, err := db.RestoreLogs(trashIDs)
+	if err != nil {
+		return nil, err
+	}
+	for i := range restored {
+		restored[i].CalendarEventID = ""
+	}
+	return restored, syncCalendarEvents(restored)
+}
+
+// Undo reverts the most recent delete or edit, or batch of them, and
+// describes what it did. A merge is undone by reverting the kept log and
+// restoring the ones merged into it.
+func Undo() (string, error) {
+	trashed, revisions, err := db.LastChange()
+	if err != nil {
+		return "", err
+	}
+	if len(trashed) == 0 && len(revisions) == 0 {
+		return "", fmt.Errorf("nothing to undo")
+	}
+	var done []string
+	if len(revisions) > 0 {
+		ids := make([]int, len(revisions))
+		for i, r := range revisions {
+			ids[i] = r.ID
+		}
+		reverted, err := db.RevertLogs(ids)
+		if err != nil {
+			return "", err
+		}
+		if err := syncCalendarEvents(reverted); err != nil {
+			return "", err
+		}
+		done = append(done, "Reverted edit of "+describeLogs(reverted))
+	}
+	if len(trashed) > 0 {
+		ids := make([]int, len(trashed))
+		for i, t := range trashed {
+			ids[i] = t.ID
+		}
+		restored, err := restoreLogs(ids)
+		if err != nil {
+			return "", err
+		}
+		done = append(done, "Restored "+describeLogs(restored))
+	}
+	return strings.Join(done, "; "), nil
+}
+
+// describeLogs names a single log, or counts several.
+func describeLogs(logs []model.Log) string {
+	if len(logs) == 1 {
+		return fmt.Sprintf("%s (%s)", logs[0].QuestionID, logs[0].Platform)
+	}
+	return fmt.Sprintf("%d logs", len(logs))
+}
@@ -0,0 +1,24 @@
+package core
+
+import (
+	"log"
+	"time"
+
+	"github.com/Harschmann/Todo-/calendar"
+	"github.com/Harschmann/Todo-/db"
+	"github.com/Harschmann/Todo-/model"
+)
+
+// AddLog saves a new log, dated now unless it has a date, and adds it to the
+// calendar. Calendar failures are logged and do not stop the log being saved.
+func AddLog(logEntry *model.Log) error {
+	if logEntry.Date.IsZero() {
+		logEntry.Date = time.Now()
+	}
+	eventID, err := calendar.AddLogToCalendar(logEntry)
+	if err != nil {
+		log.Printf("Could not add calendar event: %v", err)
+	}
+	logEntry.CalendarEventID = eventID
+	return db.SaveLog(logEntry)
+}
@@ -0,0 +1,55 @@
+package core
+
+import (
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/Harschmann/Todo-/model"
+)
+
+const (
+	MinGrade = 0
+	MaxGrade = 5
+
+	defaultEaseFactor = 2.5
+	minEaseFactor     = 1.3
+)
+
+// GradeReview applies the SM-2 algorithm to a review. The grade is the quality
+// of recall, from 0 (complete blackout) to 5 (perfect recall); grades below 3
+// restart the repetition sequence.
+func GradeReview(r *model.Review, grade int, now time.Time) error {
+	if grade < MinGrade || grade > MaxGrade {
+		return fmt.Errorf("grade must be between %d and %d, got %d", MinGrade, MaxGrade, grade)
+	}
+	if r.EaseFactor == 0 {
+		r.EaseFactor = defaultEaseFactor
+	}
+
+	if grade >= 3 {
+		switch r.Repetitions {
+		case 0:
+			r.Interval = 1
+		case 1:
+			r.Interval = 6
+		default:
+			r.Interval = int(math.Round(float64(r.Interval) * r.EaseFactor))
+		}
+		r.Repetitions++
+	} else {
+		r.Repetitions = 0
+		r.Interval = 1
+	}
+
+	q := float64(MaxGrade - grade)
+	r.EaseFactor += 0.1 - q*(0.08+q*0.02)
+	if r.EaseFactor < minEaseFactor {
+		r.EaseFactor = minEaseFactor
+	}
+
+	year, month, day := now.Date()
+	r.LastReviewed = now
+	r.Due = time.Date(year, month, day, 0, 0, 0, 0, now.Location()).AddDate(0, 0, r.Interval)
+	return nil
+}
@@ -0,0 +1,55 @@
+package core
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/Harschmann/Todo-/model"
+)
+
+func TestGradeReview(t *testing.T) {
+	now := time.Date(2026, 3, 11, 21, 30, 0, 0, time.Local)
+	tests := []struct {
+		name        string
+		review      model.Review
+		grade       int
+		interval    int
+		repetitions int
+		easeFactor  float64
+	}{
+		{"first review", model.Review{}, 4, 1, 1, 2.5},
+		{"second review", model.Review{Repetitions: 1, Interval: 1, EaseFactor: 2.5}, 4, 6, 2, 2.5},
+		{"later review", model.Review{Repetitions: 2, Interval: 6, EaseFactor: 2.5}, 5, 15, 3, 2.6},
+		{"hard recall", model.Review{Repetitions: 2, Interval: 6, EaseFactor: 2.5}, 3, 15, 3, 2.36},
+		{"lapse restarts", model.Review{Repetitions: 4, Interval: 30, EaseFactor: 2.5}, 2, 1, 0, 2.18},
+		{"ease has a floor", model.Review{Repetitions: 1, Interval: 1, EaseFactor: 1.3}, 0, 1, 0, 1.3},
+	}
+	for _, tt := range tests {
+		r := tt.review
+		if err := GradeReview(&r, tt.grade, now); err != nil {
+			t.Errorf("%s: %v", tt.name, err)
+			continue
+		}
+		if r.Interval != tt.interval || r.Repetitions != tt.repetitions || math.Abs(r.EaseFactor-tt.easeFactor) > 1e-9 {
+			t.Errorf("%s: interval %d, repetitions %d, ease %.2f; want %d, %d, %.2f",
+				tt.name, r.Interval, r.Repetitions, r.EaseFactor, tt.interval, tt.repetitions, tt.easeFactor)
+		}
+		want := time.Date(2026, 3, 11, 0, 0, 0, 0, time.Local).AddDate(0, 0, tt.interval)
+		if !r.Due.Equal(want) || !r.LastReviewed.Equal(now) {
+			t.Errorf("%s: due %v, last reviewed %v; want %v, %v", tt.name, r.Due, r.LastReviewed, want, now)
+		}
+	}
+}
+
+func TestGradeReviewRejectsGrades(t *testing.T) {
+	for _, grade := range []int{MinGrade - 1, MaxGrade + 1} {
+		r := model.Review{Interval: 6, Repetitions: 2}
+		if err := GradeReview(&r, grade, time.Now()); err == nil {
+			t.Errorf("GradeReview(%d) succeeded, want an error", grade)
+		}
+		if r.Interval != 6 || r.Repetitions != 2 {
+			t.Errorf("GradeReview(%d) changed the review to %+v", grade, r)
+		}
+	}
+}
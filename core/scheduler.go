@@ -1,28 +1,73 @@
 package core
 
 import (
+	"fmt"
 	"log"
-	// "time"
+	"strings"
 
 	"github.com/Harschmann/Todo-/calendar"
 	"github.com/Harschmann/Todo-/db"
+	"github.com/Harschmann/Todo-/model"
 )
 
-// CheckAndSendReminder checks if a problem was solved today and sends an email if not.
-// This is now a simple, single-action function.
+// CheckAndSendReminder sends an email when today's goals are not met yet.
+// Without any daily goals it falls back to checking that something was solved today.
 func CheckAndSendReminder() {
 	stats, err := db.GetDailyStats()
 	if err != nil {
 		log.Printf("Reminder check failed: could not get stats: %v", err)
 		return
 	}
+	progress, err := db.GetGoalProgress()
+	if err != nil {
+		log.Printf("Reminder check failed: could not evaluate goals: %v", err)
+		return
+	}
+
+	var daily []db.GoalProgress
+	for _, p := range progress {
+		if p.Goal.Period == model.PeriodDay {
+			daily = append(daily, p)
+		}
+	}
 
-	if stats.SolvedToday == 0 {
-		log.Println("Condition met (0 pro`blems solved today). Sending daily reminder email...")
-		if err := calendar.SendReminderEmail(); err != nil {
+	if len(daily) == 0 {
+		if stats.SolvedToday > 0 {
+			log.Printf("Condition not met (%d problems solved today). No reminder sent.", stats.SolvedToday)
+			return
+		}
+		log.Println("Condition met (0 problems solved today). Sending daily reminder email...")
+		if err := calendar.SendReminderEmail(""); err != nil {
 			log.Printf("Failed to send reminder email: %v", err)
 		}
-	} else {
-		log.Printf("Condition not met (%d problems solved today). No reminder sent.", stats.SolvedToday)
+		return
+	}
+
+	status := GoalReminderStatus(daily)
+	if status == "" {
+		log.Printf("All %d daily goals met. No reminder sent.", len(daily))
+		return
+	}
+	log.Printf("Daily goals not met. Sending reminder email:\n%s", status)
+	if err := calendar.SendReminderEmail(status); err != nil {
+		log.Printf("Failed to send reminder email: %v", err)
+	}
+}
+
+// GoalReminderStatus describes how far off each unmet goal is, one per line.
+// It returns an empty string when every goal is met.
+func GoalReminderStatus(progress []db.GoalProgress) string {
+	var lines []string
+	for _, p := range progress {
+		if p.Met() {
+			continue
+		}
+		unit := p.Goal.Metric
+		if p.Remaining() == 1 {
+			unit = strings.TrimSuffix(unit, "s")
+		}
+		lines = append(lines, fmt.Sprintf("You're %d %s short of your goal of %s (%d/%d so far).",
+			p.Remaining(), unit, p.Goal, p.Current, p.Goal.Target))
 	}
-}
\ No newline at end of file
+	return strings.Join(lines, "\n")
+}
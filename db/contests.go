@@ -0,0 +1,178 @@
+package db
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"sort"
+
+	"github.com/Harschmann/Todo-/model"
+	"go.etcd.io/bbolt"
+)
+
+var contestBucket = []byte("contests")
+
+// RatingPoint is a platform rating right after a contest. Known is false
+// until a contest on the platform has a recorded NewRating to count from.
+type RatingPoint struct {
+	Contest model.Contest
+	Rating  int
+	Known   bool
+}
+
+// ContestSummary aggregates all contests on one platform.
+type ContestSummary struct {
+	Platform   string
+	Contests   int
+	BestRank   int
+	TotalDelta int
+	Solved     int
+	History    []RatingPoint // Oldest first
+}
+
+func SaveContest(c *model.Contest) error {
+	if c.Platform == "" || c.Name == "" {
+		return fmt.Errorf("a contest needs a platform and a name")
+	}
+	return db.Update(func(tx *bbolt.Tx) error {
+		b := tx.Bucket(contestBucket)
+		if c.ID == 0 {
+			id, err := b.NextSequence()
+			if err != nil {
+				return err
+			}
+			c.ID = int(id)
+		}
+		encoded, err := json.Marshal(c)
+		if err != nil {
+			return err
+		}
+		return b.Put(itob(c.ID), encoded)
+	})
+}
+
+func GetContest(id int) (model.Contest, error) {
+	var c model.Contest
+	err := db.View(func(tx *bbolt.Tx) error {
+		v := tx.Bucket(contestBucket).Get(itob(id))
+		if v == nil {
+			return fmt.Errorf("no contest with ID %d", id)
+		}
+		return json.Unmarshal(v, &c)
+	})
+	return c, err
+}
+
+// GetAllContests returns every contest, most recent first.
+func GetAllContests() ([]model.Contest, error) {
+	var contests []model.Contest
+	err := db.View(func(tx *bbolt.Tx) error {
+		return tx.Bucket(contestBucket).ForEach(func(k, v []byte) error {
+			var c model.Contest
+			if err := json.Unmarshal(v, &c); err != nil {
+				log.Printf("could not unmarshal contest: %v", err)
+				return nil
+			}
+			contests = append(contests, c)
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(contests, func(i, j int) bool { return contests[i].Date.After(contests[j].Date) })
+	return contests, nil
+}
+
+// DeleteContest removes a contest and detaches its logs in the same transaction.
+func DeleteContest(id int) error {
+	return db.Update(func(tx *bbolt.Tx) error {
+		cb := tx.Bucket(contestBucket)
+		if cb.Get(itob(id)) == nil {
+			return fmt.Errorf("no contest with ID %d", id)
+		}
+		lb := tx.Bucket(logBucket)
+		c := lb.Cursor()
+		for k, v := c.First(); k != nil; k, v = c.Next() {
+			var logEntry model.Log
+			if err := json.Unmarshal(v, &logEntry); err != nil || logEntry.ContestID != id {
+				continue
+			}
+			logEntry.ContestID = 0
+			encoded, err := json.Marshal(logEntry)
+			if err != nil {
+				return err
+			}
+			if err := lb.Put(k, encoded); err != nil {
+				return err
+			}
+		}
+		return cb.Delete(itob(id))
+	})
+}
+
+// GetContestLogs returns the logs attached to a contest, oldest first.
+func GetContestLogs(id int) ([]model.Log, error) {
+	logs, err := GetAllLogs()
+	if err != nil {
+		return nil, err
+	}
+	var result []model.Log
+	for _, logEntry := range logs {
+		if logEntry.ContestID == id {
+			result = append(result, logEntry)
+		}
+	}
+	return result, nil
+}
+
+// GetContestSummaries aggregates contests per platform, including the rating
+// progression. When a contest has no recorded NewRating, the rating is
+// carried forward from the previous contest using the rating delta, and is
+// unknown if no earlier contest has a rating either.
+func GetContestSummaries() ([]ContestSummary, error) {
+	contests, err := GetAllContests()
+	if err != nil {
+		return nil, err
+	}
+	logs, err := GetAllLogs()
+	if err != nil {
+		return nil, err
+	}
+	solved := make(map[int]int)
+	for _, logEntry := range logs {
+		if logEntry.ContestID != 0 && counts(logEntry) {
+			solved[logEntry.ContestID]++
+		}
+	}
+
+	byPlatform := make(map[string]*ContestSummary)
+	var platforms []string
+	for i := len(contests) - 1; i >= 0; i-- {
+		c := contests[i]
+		s, ok := byPlatform[c.Platform]
+		if !ok {
+			s = &ContestSummary{Platform: c.Platform}
+			byPlatform[c.Platform] = s
+			platforms = append(platforms, c.Platform)
+		}
+		s.Contests++
+		s.TotalDelta += c.RatingDelta
+		s.Solved += solved[c.ID]
+		if c.Rank > 0 && (s.BestRank == 0 || c.Rank < s.BestRank) {
+			s.BestRank = c.Rank
+		}
+		point := RatingPoint{Contest: c, Rating: c.NewRating, Known: c.NewRating != 0}
+		if n := len(s.History); !point.Known && n > 0 && s.History[n-1].Known {
+			point.Rating, point.Known = s.History[n-1].Rating+c.RatingDelta, true
+		}
+		s.History = append(s.History, point)
+	}
+
+	sort.Strings(platforms)
+	summaries := make([]ContestSummary, len(platforms))
+	for i, p := range platforms {
+		summaries[i] = *byPlatform[p]
+	}
+	return summaries, nil
+}
@@ -0,0 +1,108 @@
+package db
+
+import (
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/Harschmann/Todo-/model"
+	"go.etcd.io/bbolt"
+)
+
+// Merge folds the logs stored at the Removed dates into Kept, which replaces
+// the log stored at Kept.Date.
+type Merge struct {
+	Kept    model.Log
+	Removed []time.Time
+}
+
+// duplicateKey identifies the logs that can duplicate each other: those of
+// one problem with one status. Logs with different statuses, such as a wrong
+// answer followed by an accepted one, are separate attempts.
+func duplicateKey(l model.Log) string {
+	return l.ProblemKey() + "\x00" + strings.ToLower(l.EffectiveStatus())
+}
+
+// FindDuplicate returns the log of the same problem and status nearest to
+// l's date and at most window away from it, other than l itself. It reports
+// false if there is none or window is not positive.
+func FindDuplicate(l model.Log, window time.Duration) (model.Log, bool, error) {
+	if window <= 0 {
+		return model.Log{}, false, nil
+	}
+	logs, err := GetAllLogs()
+	if err != nil {
+		return model.Log{}, false, err
+	}
+	var nearest model.Log
+	found := false
+	for _, other := range logs {
+		if other.Date.Equal(l.Date) || duplicateKey(other) != duplicateKey(l) {
+			continue
+		}
+		gap := other.Date.Sub(l.Date).Abs()
+		if gap <= window && (!found || gap < nearest.Date.Sub(l.Date).Abs()) {
+			nearest, found = other, true
+		}
+	}
+	return nearest, found, nil
+}
+
+// DuplicateGroups returns groups of logs of the same problem and status that
+// were all logged within window of the group's first log, oldest first. Logs without
+// a duplicate are left out.
+func DuplicateGroups(window time.Duration) ([][]model.Log, error) {
+	logs, err := GetAllLogs()
+	if err != nil {
+		return nil, err
+	}
+	sort.SliceStable(logs, func(i, j int) bool { return logs[i].Date.Before(logs[j].Date) })
+	open := make(map[string][]model.Log)
+	var groups [][]model.Log
+	flush := func(key string) {
+		if len(open[key]) > 1 {
+			groups = append(groups, open[key])
+		}
+		delete(open, key)
+	}
+	for _, l := range logs {
+		key := duplicateKey(l)
+		if group := open[key]; len(group) > 0 && l.Date.Sub(group[0].Date) > window {
+			flush(key)
+		}
+		open[key] = append(open[key], l)
+	}
+	for key := range open {
+		flush(key)
+	}
+	sort.Slice(groups, func(i, j int) bool { return groups[i][0].Date.Before(groups[j][0].Date) })
+	return groups, nil
+}
+
+// MergeLogs applies merges in one transaction. The removed logs go to the
+// trash and their solutions move to the kept log, so undoing the merge
+// reverts the kept log and restores the others with their solutions.
+func MergeLogs(merges []Merge) error {
+	return db.Update(func(tx *bbolt.Tx) error {
+		now := time.Now()
+		for i := range merges {
+			m := &merges[i]
+			for _, date := range m.Removed {
+				trashed, err := trashLog(tx, date, now)
+				if err != nil {
+					return err
+				}
+				for _, s := range trashed.Solutions {
+					s.LogDate = m.Kept.Date
+					if err := putSolution(tx, s); err != nil {
+						return err
+					}
+				}
+			}
+			if err := updateLog(tx, &m.Kept, now); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
@@ -0,0 +1,177 @@
+package db
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/Harschmann/Todo-/model"
+	"github.com/Harschmann/Todo-/utils"
+	"go.etcd.io/bbolt"
+)
+
+var goalBucket = []byte("goals")
+
+// GoalProgress is a goal evaluated against the logs of its current period.
+type GoalProgress struct {
+	Goal    model.Goal
+	Current int
+	Start   time.Time
+	End     time.Time
+}
+
+// Percent returns how much of the goal is done, capped at 1.
+func (p GoalProgress) Percent() float64 {
+	if p.Goal.Target <= 0 {
+		return 1
+	}
+	pct := float64(p.Current) / float64(p.Goal.Target)
+	if pct > 1 {
+		return 1
+	}
+	return pct
+}
+
+// Remaining returns how many problems or minutes are still missing.
+func (p GoalProgress) Remaining() int {
+	if p.Current >= p.Goal.Target {
+		return 0
+	}
+	return p.Goal.Target - p.Current
+}
+
+func (p GoalProgress) Met() bool {
+	return p.Remaining() == 0
+}
+
+func itob(v int) []byte {
+	b := make([]byte, 8)
+	binary.BigEndian.PutUint64(b, uint64(v))
+	return b
+}
+
+func validateGoal(g *model.Goal) error {
+	switch g.Metric {
+	case model.MetricProblems, model.MetricMinutes:
+	default:
+		return fmt.Errorf("unknown goal metric %q (want %q or %q)", g.Metric, model.MetricProblems, model.MetricMinutes)
+	}
+	switch g.Period {
+	case model.PeriodDay, model.PeriodWeek, model.PeriodMonth:
+	default:
+		return fmt.Errorf("unknown goal period %q (want day, week or month)", g.Period)
+	}
+	if g.Target <= 0 {
+		return fmt.Errorf("goal target must be positive, got %d", g.Target)
+	}
+	return nil
+}
+
+func SaveGoal(g *model.Goal) error {
+	if err := validateGoal(g); err != nil {
+		return err
+	}
+	return db.Update(func(tx *bbolt.Tx) error {
+		b := tx.Bucket(goalBucket)
+		if g.ID == 0 {
+			id, err := b.NextSequence()
+			if err != nil {
+				return err
+			}
+			g.ID = int(id)
+			g.Created = time.Now()
+		}
+		encoded, err := json.Marshal(g)
+		if err != nil {
+			return err
+		}
+		return b.Put(itob(g.ID), encoded)
+	})
+}
+
+func GetAllGoals() ([]model.Goal, error) {
+	var goals []model.Goal
+	err := db.View(func(tx *bbolt.Tx) error {
+		return tx.Bucket(goalBucket).ForEach(func(k, v []byte) error {
+			var g model.Goal
+			if err := json.Unmarshal(v, &g); err != nil {
+				log.Printf("could not unmarshal goal: %v", err)
+				return nil
+			}
+			goals = append(goals, g)
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	return goals, nil
+}
+
+func DeleteGoal(id int) error {
+	return db.Update(func(tx *bbolt.Tx) error {
+		b := tx.Bucket(goalBucket)
+		if b.Get(itob(id)) == nil {
+			return fmt.Errorf("no goal with ID %d", id)
+		}
+		return b.Delete(itob(id))
+	})
+}
+
+// periodBounds returns the calendar day, week (starting Monday) or month containing now.
+func periodBounds(period string, now time.Time) (time.Time, time.Time) {
+	start := utils.StartOfDay(now)
+	switch period {
+	case model.PeriodWeek:
+		start = utils.StartOfWeek(now)
+		return start, start.AddDate(0, 0, 7)
+	case model.PeriodMonth:
+		start = start.AddDate(0, 0, 1-start.Day())
+		return start, start.AddDate(0, 1, 0)
+	default:
+		return start, start.AddDate(0, 0, 1)
+	}
+}
+
+// EvaluateGoal measures a goal against the given logs for the period containing now.
+func EvaluateGoal(g model.Goal, logs []model.Log, now time.Time) GoalProgress {
+	start, end := periodBounds(g.Period, now)
+	progress := GoalProgress{Goal: g, Start: start, End: end}
+	for _, logEntry := range logs {
+		if logEntry.Date.Before(start) || !logEntry.Date.Before(end) {
+			continue
+		}
+		if g.Topic != "" && !strings.EqualFold(logEntry.Topic, g.Topic) {
+			continue
+		}
+		if g.Platform != "" && !strings.EqualFold(logEntry.Platform, g.Platform) {
+			continue
+		}
+		if g.Metric == model.MetricMinutes {
+			progress.Current += logEntry.TimeSpent
+		} else if counts(logEntry) {
+			progress.Current++
+		}
+	}
+	return progress
+}
+
+func GetGoalProgress() ([]GoalProgress, error) {
+	goals, err := GetAllGoals()
+	if err != nil {
+		return nil, err
+	}
+	logs, err := GetAllLogs()
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	progress := make([]GoalProgress, len(goals))
+	for i, g := range goals {
+		progress[i] = EvaluateGoal(g, logs, now)
+	}
+	return progress, nil
+}
@@ -0,0 +1,72 @@
+package db
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Harschmann/Todo-/model"
+)
+
+func TestPeriodBounds(t *testing.T) {
+	day := func(month time.Month, d int) time.Time { return time.Date(2026, month, d, 0, 0, 0, 0, time.Local) }
+	// A Wednesday evening.
+	now := time.Date(2026, 3, 11, 21, 30, 0, 0, time.Local)
+	tests := []struct {
+		period     string
+		start, end time.Time
+	}{
+		{model.PeriodDay, day(3, 11), day(3, 12)},
+		{model.PeriodWeek, day(3, 9), day(3, 16)},
+		{model.PeriodMonth, day(3, 1), day(4, 1)},
+		{"", day(3, 11), day(3, 12)},
+	}
+	for _, tt := range tests {
+		start, end := periodBounds(tt.period, now)
+		if !start.Equal(tt.start) || !end.Equal(tt.end) {
+			t.Errorf("periodBounds(%q) = %v, %v, want %v, %v", tt.period, start, end, tt.start, tt.end)
+		}
+	}
+
+	// A Sunday still belongs to the week that started on Monday.
+	start, _ := periodBounds(model.PeriodWeek, time.Date(2026, 3, 15, 23, 0, 0, 0, time.Local))
+	if !start.Equal(day(3, 9)) {
+		t.Errorf("week of Sunday 2026-03-15 starts %v, want Monday 2026-03-09", start)
+	}
+	// December rolls over into the next year.
+	_, end := periodBounds(model.PeriodMonth, time.Date(2026, 12, 31, 12, 0, 0, 0, time.Local))
+	if want := time.Date(2027, 1, 1, 0, 0, 0, 0, time.Local); !end.Equal(want) {
+		t.Errorf("end of December = %v, want %v", end, want)
+	}
+}
+
+func TestEvaluateGoal(t *testing.T) {
+	now := time.Date(2026, 3, 11, 21, 30, 0, 0, time.Local)
+	logs := []model.Log{
+		{Date: now.Add(-time.Hour), Topic: "DP", Platform: "Codeforces", Status: model.StatusAccepted, TimeSpent: 40},
+		{Date: now.Add(-2 * time.Hour), Topic: "Greedy", Platform: "LeetCode", Status: model.StatusAttempted, TimeSpent: 25},
+		{Date: now.AddDate(0, 0, -1), Topic: "dp", Platform: "LeetCode", TimeSpent: 30},
+		{Date: now.AddDate(0, 0, -3), Topic: "DP", Platform: "Codeforces", Status: model.StatusAccepted, TimeSpent: 50},
+	}
+	tests := []struct {
+		goal model.Goal
+		want int
+	}{
+		{model.Goal{Metric: model.MetricProblems, Period: model.PeriodDay}, 1},
+		{model.Goal{Metric: model.MetricMinutes, Period: model.PeriodDay}, 65},
+		{model.Goal{Metric: model.MetricProblems, Period: model.PeriodWeek}, 2},
+		{model.Goal{Metric: model.MetricProblems, Period: model.PeriodMonth}, 3},
+		{model.Goal{Metric: model.MetricProblems, Period: model.PeriodWeek, Topic: "DP"}, 2},
+		{model.Goal{Metric: model.MetricMinutes, Period: model.PeriodWeek, Platform: "leetcode"}, 55},
+	}
+	for _, tt := range tests {
+		got := EvaluateGoal(tt.goal, logs, now)
+		if got.Current != tt.want {
+			t.Errorf("EvaluateGoal(%s) = %d, want %d", tt.goal, got.Current, tt.want)
+		}
+	}
+
+	p := EvaluateGoal(model.Goal{Metric: model.MetricMinutes, Period: model.PeriodDay, Target: 50}, logs, now)
+	if p.Percent() != 1 || p.Remaining() != 0 || !p.Met() {
+		t.Errorf("65 of 50 minutes: Percent %v, Remaining %d, Met %v; want 1, 0, true", p.Percent(), p.Remaining(), p.Met())
+	}
+}
@@ -0,0 +1,388 @@
+package db
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"sort"
+	"time"
+
+	"github.com/Harschmann/Todo-/model"
+	"go.etcd.io/bbolt"
+)
+
+var (
+	trashBucket    = []byte("trash")
+	revisionBucket = []byte("revisions")
+)
+
+func putTrash(tx *bbolt.Tx, trashed *model.TrashedLog) error {
+	b := tx.Bucket(trashBucket)
+	id, err := b.NextSequence()
+	if err != nil {
+		return err
+	}
+	trashed.ID = int(id)
+	encoded, err := json.Marshal(trashed)
+	if err != nil {
+		return err
+	}
+	return b.Put(itob(trashed.ID), encoded)
+}
+
+// GetTrash returns the deleted logs, most recently deleted first.
+func GetTrash() ([]model.TrashedLog, error) {
+	var trash []model.TrashedLog
+	err := db.View(func(tx *bbolt.Tx) error {
+		return tx.Bucket(trashBucket).ForEach(func(k, v []byte) error {
+			var t model.TrashedLog
+			if err := json.Unmarshal(v, &t); err != nil {
+				log.Printf("could not unmarshal trashed log: %v", err)
+				return nil
+			}
+			trash = append(trash, t)
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(trash, func(i, j int) bool { return trash[i].Deleted.After(trash[j].Deleted) })
+	return trash, nil
+}
+
+// RestoreLog moves a log and its solutions out of the trash.
+func RestoreLog(trashID int) (model.Log, error) {
+	restored, err := RestoreLogs([]int{trashID})
+	if err != nil {
+		return model.Log{}, err
+	}
+	return restored[0], nil
+}
+
+// RestoreLogs moves several logs out of the trash in one transaction.
+func RestoreLogs(trashIDs []int) ([]model.Log, error) {
+	var restored []model.Log
+	err := db.Update(func(tx *bbolt.Tx) error {
+		for _, id := range trashIDs {
+			l, err := restoreLog(tx, id)
+			if err != nil {
+				return err
+			}
+			restored = append(restored, l)
+		}
+		return nil
+	})
+	return restored, err
+}
+
+func restoreLog(tx *bbolt.Tx, trashID int) (model.Log, error) {
+	tb := tx.Bucket(trashBucket)
+	v := tb.Get(itob(trashID))
+	if v == nil {
+		return model.Log{}, fmt.Errorf("no trashed log with ID %d", trashID)
+	}
+	var trashed model.TrashedLog
+	if err := json.Unmarshal(v, &trashed); err != nil {
+		return model.Log{}, err
+	}
+	restored := trashed.Log
+
+	lb := tx.Bucket(logBucket)
+	key, err := restored.Date.MarshalText()
+	if err != nil {
+		return restored, err
+	}
+	if lb.Get(key) != nil {
+		return restored, fmt.Errorf("another log already uses %s", restored.Date.Format("2006-01-02 15:04:05"))
+	}
+	encoded, err := json.Marshal(restored)
+	if err != nil {
+		return restored, err
+	}
+	if err := lb.Put(key, encoded); err != nil {
+		return restored, err
+	}
+	if err := ensureProblem(tx, &restored); err != nil {
+		return restored, err
+	}
+	sb := tx.Bucket(solutionBucket)
+	for _, s := range trashed.Solutions {
+		// A merge may have moved the solution to another log; take it back.
+		var movedTo []byte
+		var current model.Solution
+		if v := sb.Get(itob(s.ID)); v != nil && json.Unmarshal(v, &current) == nil && !current.LogDate.Equal(s.LogDate) {
+			if movedTo, err = current.LogDate.MarshalText(); err != nil {
+				return restored, err
+			}
+		}
+		if err := putSolution(tx, s); err != nil {
+			return restored, err
+		}
+		if movedTo != nil {
+			if err := reindexLogAt(tx, movedTo); err != nil {
+				return restored, err
+			}
+		}
+	}
+	if err := indexLog(tx, key, restored); err != nil {
+		return restored, err
+	}
+	return restored, tb.Delete(itob(trashID))
+}
+
+// PurgeTrash permanently deletes logs trashed before the given time, along
+// with their revision history, and returns how many were deleted.
+func PurgeTrash(before time.Time) (int, error) {
+	purged := 0
+	err := db.Update(func(tx *bbolt.Tx) error {
+		tb := tx.Bucket(trashBucket)
+		var stale [][]byte
+		var gone []time.Time
+		err := tb.ForEach(func(k, v []byte) error {
+			var t model.TrashedLog
+			if err := json.Unmarshal(v, &t); err == nil && t.Deleted.Before(before) {
+				stale = append(stale, k)
+				gone = append(gone, t.Log.Date)
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+		for _, k := range stale {
+			if err := tb.Delete(k); err != nil {
+				return err
+			}
+		}
+		purged = len(stale)
+		return deleteRevisions(tx, func(r model.Revision) bool {
+			for _, date := range gone {
+				if r.LogDate.Equal(date) {
+					return true
+				}
+			}
+			return false
+		})
+	})
+	return purged, err
+}
+
+// sameContent reports whether two versions of a log differ only in fields
+// the app maintains itself, like the calendar event ID.
+func sameContent(a, b model.Log) bool {
+	a.CalendarEventID, b.CalendarEventID = "", ""
+	ja, errA := json.Marshal(a)
+	jb, errB := json.Marshal(b)
+	return errA == nil && errB == nil && string(ja) == string(jb)
+}
+
+// recordRevisionAt saves the current version of the log at date before it is
+// replaced by updated, unless nothing visible changes.
+func recordRevisionAt(tx *bbolt.Tx, date time.Time, updated model.Log, now time.Time) error {
+	key, err := date.MarshalText()
+	if err != nil {
+		return err
+	}
+	v := tx.Bucket(logBucket).Get(key)
+	if v == nil {
+		return nil
+	}
+	var previous model.Log
+	if err := json.Unmarshal(v, &previous); err != nil {
+		return err
+	}
+	if sameContent(previous, updated) {
+		return nil
+	}
+	b := tx.Bucket(revisionBucket)
+	id, err := b.NextSequence()
+	if err != nil {
+		return err
+	}
+	r := model.Revision{ID: int(id), LogDate: previous.Date, Log: previous, Changed: now}
+	encoded, err := json.Marshal(r)
+	if err != nil {
+		return err
+	}
+	return b.Put(itob(r.ID), encoded)
+}
+
+// moveRevisions points the revisions of the log at from to the log at to.
+func moveRevisions(tx *bbolt.Tx, from, to time.Time) error {
+	b := tx.Bucket(revisionBucket)
+	var moved []model.Revision
+	err := b.ForEach(func(k, v []byte) error {
+		var r model.Revision
+		if err := json.Unmarshal(v, &r); err == nil && r.LogDate.Equal(from) {
+			r.LogDate = to
+			moved = append(moved, r)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	for _, r := range moved {
+		encoded, err := json.Marshal(r)
+		if err != nil {
+			return err
+		}
+		if err := b.Put(itob(r.ID), encoded); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func deleteRevisions(tx *bbolt.Tx, match func(model.Revision) bool) error {
+	b := tx.Bucket(revisionBucket)
+	var stale [][]byte
+	err := b.ForEach(func(k, v []byte) error {
+		var r model.Revision
+		if err := json.Unmarshal(v, &r); err == nil && match(r) {
+			stale = append(stale, k)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	for _, k := range stale {
+		if err := b.Delete(k); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// GetRevisions returns the earlier versions of the log at logDate, oldest first.
+func GetRevisions(logDate time.Time) ([]model.Revision, error) {
+	var revisions []model.Revision
+	err := db.View(func(tx *bbolt.Tx) error {
+		return tx.Bucket(revisionBucket).ForEach(func(k, v []byte) error {
+			var r model.Revision
+			if err := json.Unmarshal(v, &r); err != nil {
+				log.Printf("could not unmarshal revision: %v", err)
+				return nil
+			}
+			if r.LogDate.Equal(logDate) {
+				revisions = append(revisions, r)
+			}
+			return nil
+		})
+	})
+	return revisions, err
+}
+
+// RevertLog puts a log back to the version saved in a revision. That revision
+// and any later ones are dropped, so reverting is itself not recorded.
+func RevertLog(revisionID int) (model.Log, error) {
+	reverted, err := RevertLogs([]int{revisionID})
+	if err != nil {
+		return model.Log{}, err
+	}
+	return reverted[0], nil
+}
+
+// RevertLogs reverts several revisions in one transaction.
+func RevertLogs(revisionIDs []int) ([]model.Log, error) {
+	var reverted []model.Log
+	err := db.Update(func(tx *bbolt.Tx) error {
+		for _, id := range revisionIDs {
+			l, err := revertLog(tx, id)
+			if err != nil {
+				return err
+			}
+			reverted = append(reverted, l)
+		}
+		return nil
+	})
+	return reverted, err
+}
+
+func revertLog(tx *bbolt.Tx, revisionID int) (model.Log, error) {
+	v := tx.Bucket(revisionBucket).Get(itob(revisionID))
+	if v == nil {
+		return model.Log{}, fmt.Errorf("no revision with ID %d", revisionID)
+	}
+	var r model.Revision
+	if err := json.Unmarshal(v, &r); err != nil {
+		return model.Log{}, err
+	}
+	key, err := r.LogDate.MarshalText()
+	if err != nil {
+		return model.Log{}, err
+	}
+	lb := tx.Bucket(logBucket)
+	current := lb.Get(key)
+	if current == nil {
+		return model.Log{}, fmt.Errorf("the log from %s was deleted", r.LogDate.Format("2006-01-02 15:04"))
+	}
+	reverted := r.Log
+	// Keep the calendar event that currently exists for the log.
+	var latest model.Log
+	if err := json.Unmarshal(current, &latest); err == nil {
+		reverted.CalendarEventID = latest.CalendarEventID
+	}
+	// Reverting an edit of the date moves the log back.
+	if err := putLogAt(tx, r.LogDate, &reverted); err != nil {
+		return reverted, err
+	}
+	return reverted, deleteRevisions(tx, func(other model.Revision) bool {
+		return other.LogDate.Equal(reverted.Date) && other.ID >= r.ID
+	})
+}
+
+// LastChange returns the most recent change that can be undone: the logs
+// deleted last, the last edits of logs that still exist, or both if they
+// happened together as in a merge. Changes made together, such as a bulk
+// delete, share a timestamp and are returned together. Both are empty if
+// there is nothing to undo.
+func LastChange() ([]model.TrashedLog, []model.Revision, error) {
+	var trashed []model.TrashedLog
+	var revisions []model.Revision
+	err := db.View(func(tx *bbolt.Tx) error {
+		err := tx.Bucket(trashBucket).ForEach(func(k, v []byte) error {
+			var t model.TrashedLog
+			if err := json.Unmarshal(v, &t); err != nil {
+				return nil
+			}
+			if len(trashed) == 0 || t.Deleted.After(trashed[0].Deleted) {
+				trashed = []model.TrashedLog{t}
+			} else if t.Deleted.Equal(trashed[0].Deleted) {
+				trashed = append(trashed, t)
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+		lb := tx.Bucket(logBucket)
+		return tx.Bucket(revisionBucket).ForEach(func(k, v []byte) error {
+			var r model.Revision
+			if err := json.Unmarshal(v, &r); err != nil {
+				return nil
+			}
+			if key, err := r.LogDate.MarshalText(); err != nil || lb.Get(key) == nil {
+				return nil
+			}
+			if len(revisions) == 0 || r.Changed.After(revisions[0].Changed) {
+				revisions = []model.Revision{r}
+			} else if r.Changed.Equal(revisions[0].Changed) {
+				revisions = append(revisions, r)
+			}
+			return nil
+		})
+	})
+	if err != nil || len(trashed) == 0 || len(revisions) == 0 {
+		return trashed, revisions, err
+	}
+	switch trashed[0].Deleted.Compare(revisions[0].Changed) {
+	case 1:
+		return trashed, nil, nil
+	case -1:
+		return nil, revisions, nil
+	}
+	return trashed, revisions, nil
+}
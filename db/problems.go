@@ -0,0 +1,328 @@
+package db
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+
+	"github.com/Harschmann/Todo-/model"
+	"github.com/Harschmann/Todo-/platform"
+	"go.etcd.io/bbolt"
+)
+
+var problemBucket = []byte("problems")
+
+// ProblemStats summarizes every logged attempt at one problem.
+type ProblemStats struct {
+	Problem   model.Problem
+	Attempts  []model.Log // Oldest first
+	TotalTime int
+}
+
+// FirstTry reports whether the very first attempt at the problem counts as
+// solved under the current config.
+func (s ProblemStats) FirstTry() bool {
+	return len(s.Attempts) > 0 && counts(s.Attempts[0])
+}
+
+// Outcome describes the problem as "first try", "retried" or "unsolved".
+func (s ProblemStats) Outcome() string {
+	switch {
+	case !s.Solved():
+		return "unsolved"
+	case s.FirstTry():
+		return "first try"
+	default:
+		return "retried"
+	}
+}
+
+// Solved reports whether any attempt at the problem counts as solved.
+func (s ProblemStats) Solved() bool {
+	for _, attempt := range s.Attempts {
+		if counts(attempt) {
+			return true
+		}
+	}
+	return false
+}
+
+// ensureProblem adds a catalog entry for the log's problem if there is none
+// yet, and fills in the canonical URL of entries that lack one.
+func ensureProblem(tx *bbolt.Tx, logEntry *model.Log) error {
+	b := tx.Bucket(problemBucket)
+	key := logEntry.ProblemKey()
+	ref, err := platform.Resolve(logEntry.Platform, logEntry.QuestionID)
+	if err != nil {
+		ref = platform.Ref{Platform: logEntry.Platform, ID: logEntry.QuestionID}
+	}
+	var problem model.Problem
+	if v := b.Get([]byte(key)); v != nil {
+		if err := json.Unmarshal(v, &problem); err != nil {
+			return fmt.Errorf("could not read problem %q: %w", key, err)
+		}
+		if problem.URL != "" || ref.URL == "" {
+			return nil
+		}
+		problem.URL = ref.URL
+	} else {
+		problem = model.Problem{
+			Key:       key,
+			Platform:  logEntry.Platform,
+			ProblemID: ref.ID,
+			Title:     ref.ID,
+			URL:       ref.URL,
+			Added:     logEntry.Date,
+		}
+	}
+	encoded, err := json.Marshal(problem)
+	if err != nil {
+		return err
+	}
+	return b.Put([]byte(key), encoded)
+}
+
+// backfillProblems moves catalog entries to their current keys and creates
+// entries for logs saved before the catalog existed.
+func backfillProblems(tx *bbolt.Tx) error {
+	if err := migrateProblemKeys(tx); err != nil {
+		return err
+	}
+	return tx.Bucket(logBucket).ForEach(func(k, v []byte) error {
+		var logEntry model.Log
+		if err := json.Unmarshal(v, &logEntry); err != nil {
+			return nil
+		}
+		return ensureProblem(tx, &logEntry)
+	})
+}
+
+// migrateProblemKeys moves catalog entries saved before question IDs were
+// normalized, e.g. under "atcoder:abc300 a", to their current key, so their
+// edited titles, tags and URLs stay with the problem. If an entry already
+// exists under the current key, the old entry fills in what it lacks.
+func migrateProblemKeys(tx *bbolt.Tx) error {
+	b := tx.Bucket(problemBucket)
+	type move struct {
+		from, to string
+		problem  model.Problem
+	}
+	var moves []move
+	err := b.ForEach(func(k, v []byte) error {
+		var problem model.Problem
+		if err := json.Unmarshal(v, &problem); err != nil {
+			return fmt.Errorf("could not read problem %q: %w", k, err)
+		}
+		_, id, _ := strings.Cut(string(k), ":")
+		if key := (model.Log{Platform: problem.Platform, QuestionID: id}).ProblemKey(); key != string(k) {
+			moves = append(moves, move{from: string(k), to: key, problem: problem})
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	for _, m := range moves {
+		problem := m.problem
+		if v := b.Get([]byte(m.to)); v != nil {
+			var current model.Problem
+			if err := json.Unmarshal(v, &current); err != nil {
+				return fmt.Errorf("could not read problem %q: %w", m.to, err)
+			}
+			problem = fillProblem(current, problem)
+		} else {
+			problem.ProblemID = platform.NormalizeID(problem.Platform, problem.ProblemID)
+		}
+		problem.Key = m.to
+		encoded, err := json.Marshal(problem)
+		if err != nil {
+			return err
+		}
+		if err := b.Put([]byte(m.to), encoded); err != nil {
+			return err
+		}
+		if err := b.Delete([]byte(m.from)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// fillProblem returns p with the details it lacks taken from other. A title
+// that is just the problem ID counts as lacking.
+func fillProblem(p, other model.Problem) model.Problem {
+	if p.Title == "" || p.Title == p.ProblemID {
+		p.Title = other.Title
+	}
+	if p.URL == "" {
+		p.URL = other.URL
+	}
+	if p.Rating == 0 {
+		p.Rating = other.Rating
+	}
+	if len(p.Tags) == 0 {
+		p.Tags = other.Tags
+	}
+	if !other.Added.IsZero() && (p.Added.IsZero() || other.Added.Before(p.Added)) {
+		p.Added = other.Added
+	}
+	return p
+}
+
+func GetProblem(key string) (model.Problem, error) {
+	var problem model.Problem
+	err := db.View(func(tx *bbolt.Tx) error {
+		v := tx.Bucket(problemBucket).Get([]byte(key))
+		if v == nil {
+			return fmt.Errorf("no problem %q in the catalog", key)
+		}
+		return json.Unmarshal(v, &problem)
+	})
+	return problem, err
+}
+
+// ProblemURL returns the catalog URL of a log's problem, falling back to the
+// URL derived from its platform and question ID.
+func ProblemURL(logEntry model.Log) string {
+	if problem, err := GetProblem(logEntry.ProblemKey()); err == nil && problem.URL != "" {
+		return problem.URL
+	}
+	ref, err := platform.Resolve(logEntry.Platform, logEntry.QuestionID)
+	if err != nil {
+		return ""
+	}
+	return ref.URL
+}
+
+func GetAllProblems() ([]model.Problem, error) {
+	var problems []model.Problem
+	err := db.View(func(tx *bbolt.Tx) error {
+		return tx.Bucket(problemBucket).ForEach(func(k, v []byte) error {
+			var problem model.Problem
+			if err := json.Unmarshal(v, &problem); err != nil {
+				log.Printf("could not unmarshal problem: %v", err)
+				return nil
+			}
+			problems = append(problems, problem)
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	return problems, nil
+}
+
+func SaveProblem(problem *model.Problem) error {
+	if problem.Key == "" {
+		problem.Key = model.Log{Platform: problem.Platform, QuestionID: problem.ProblemID}.ProblemKey()
+	}
+	return db.Update(func(tx *bbolt.Tx) error {
+		encoded, err := json.Marshal(problem)
+		if err != nil {
+			return err
+		}
+		return tx.Bucket(problemBucket).Put([]byte(problem.Key), encoded)
+	})
+}
+
+// GetProblemStats groups all logs by problem, most recently attempted first.
+func GetProblemStats() ([]ProblemStats, error) {
+	problems, err := GetAllProblems()
+	if err != nil {
+		return nil, err
+	}
+	logs, err := GetAllLogs()
+	if err != nil {
+		return nil, err
+	}
+	byKey := make(map[string]*ProblemStats, len(problems))
+	for _, problem := range problems {
+		byKey[problem.Key] = &ProblemStats{Problem: problem}
+	}
+	// Logs are keyed by date, so they arrive oldest first.
+	for _, logEntry := range logs {
+		stats, ok := byKey[logEntry.ProblemKey()]
+		if !ok {
+			continue
+		}
+		stats.Attempts = append(stats.Attempts, logEntry)
+		stats.TotalTime += logEntry.TimeSpent
+	}
+
+	var result []ProblemStats
+	for _, stats := range byKey {
+		if len(stats.Attempts) > 0 {
+			result = append(result, *stats)
+		}
+	}
+	sort.Slice(result, func(i, j int) bool {
+		a, b := result[i].Attempts, result[j].Attempts
+		return a[len(a)-1].Date.After(b[len(b)-1].Date)
+	})
+	return result, nil
+}
+
+// AttemptNumber returns which attempt at its problem a log is (1-based) and
+// the total number of attempts at that problem.
+func AttemptNumber(logEntry model.Log) (int, int, error) {
+	logs, err := GetAllLogs()
+	if err != nil {
+		return 0, 0, err
+	}
+	n, total := 0, 0
+	for _, other := range logs {
+		if other.ProblemKey() != logEntry.ProblemKey() {
+			continue
+		}
+		total++
+		if !other.Date.After(logEntry.Date) {
+			n++
+		}
+	}
+	return n, total, nil
+}
+
+// GetUpsolveBacklog returns the problems that were attempted but never
+// solved, most recently attempted first.
+func GetUpsolveBacklog() ([]ProblemStats, error) {
+	stats, err := GetProblemStats()
+	if err != nil {
+		return nil, err
+	}
+	var backlog []ProblemStats
+	for _, s := range stats {
+		if !s.Solved() {
+			backlog = append(backlog, s)
+		}
+	}
+	return backlog, nil
+}
+
+// mergeProblem fills in catalog fields that are still empty from an imported problem.
+func mergeProblem(tx *bbolt.Tx, key string, imported model.Problem) error {
+	b := tx.Bucket(problemBucket)
+	var problem model.Problem
+	if err := json.Unmarshal(b.Get([]byte(key)), &problem); err != nil {
+		return err
+	}
+	if imported.Title != "" && (problem.Title == "" || problem.Title == problem.ProblemID) {
+		problem.Title = imported.Title
+	}
+	if problem.URL == "" {
+		problem.URL = imported.URL
+	}
+	if problem.Rating == 0 {
+		problem.Rating = imported.Rating
+	}
+	if len(problem.Tags) == 0 {
+		problem.Tags = imported.Tags
+	}
+	encoded, err := json.Marshal(problem)
+	if err != nil {
+		return err
+	}
+	return b.Put([]byte(key), encoded)
+}
@@ -0,0 +1,98 @@
+package db
+
+import (
+	"encoding/json"
+	"log"
+	"sort"
+	"time"
+
+	"github.com/Harschmann/Todo-/model"
+	"github.com/Harschmann/Todo-/utils"
+	"go.etcd.io/bbolt"
+)
+
+var reviewBucket = []byte("reviews")
+
+func SaveReview(r *model.Review) error {
+	return db.Update(func(tx *bbolt.Tx) error {
+		encoded, err := json.Marshal(r)
+		if err != nil {
+			return err
+		}
+		return tx.Bucket(reviewBucket).Put([]byte(r.ProblemKey), encoded)
+	})
+}
+
+// GetReviewQueue returns the review state of every solved problem. Problems
+// that were never reviewed are first due the day after they were first solved.
+func GetReviewQueue() ([]model.Review, error) {
+	logs, err := GetAllLogs()
+	if err != nil {
+		return nil, err
+	}
+	stored := make(map[string]model.Review)
+	err = db.View(func(tx *bbolt.Tx) error {
+		return tx.Bucket(reviewBucket).ForEach(func(k, v []byte) error {
+			var r model.Review
+			if err := json.Unmarshal(v, &r); err != nil {
+				log.Printf("could not unmarshal review: %v", err)
+				return nil
+			}
+			stored[string(k)] = r
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	queue := make(map[string]model.Review)
+	for _, logEntry := range logs {
+		key := logEntry.ProblemKey()
+		if !counts(logEntry) {
+			continue
+		}
+		if _, seen := queue[key]; seen {
+			continue
+		}
+		if r, ok := stored[key]; ok {
+			queue[key] = r
+			continue
+		}
+		queue[key] = model.Review{
+			ProblemKey: key,
+			Platform:   logEntry.Platform,
+			QuestionID: logEntry.QuestionID,
+			Topic:      logEntry.Topic,
+			Due:        utils.StartOfDay(logEntry.Date).AddDate(0, 0, 1),
+		}
+	}
+
+	reviews := make([]model.Review, 0, len(queue))
+	for _, r := range queue {
+		reviews = append(reviews, r)
+	}
+	sort.Slice(reviews, func(i, j int) bool {
+		if !reviews[i].Due.Equal(reviews[j].Due) {
+			return reviews[i].Due.Before(reviews[j].Due)
+		}
+		return reviews[i].ProblemKey < reviews[j].ProblemKey
+	})
+	return reviews, nil
+}
+
+// GetDueReviews returns the problems due for review on or before the day of now.
+func GetDueReviews(now time.Time) ([]model.Review, error) {
+	queue, err := GetReviewQueue()
+	if err != nil {
+		return nil, err
+	}
+	endOfDay := utils.StartOfDay(now).AddDate(0, 0, 1)
+	var due []model.Review
+	for _, r := range queue {
+		if r.Due.Before(endOfDay) {
+			due = append(due, r)
+		}
+	}
+	return due, nil
+}
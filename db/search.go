@@ -0,0 +1,307 @@
+package db
+
+import (
+	"encoding/json"
+	"math"
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/Harschmann/Todo-/model"
+	"go.etcd.io/bbolt"
+)
+
+// The search index maps each term to the logs whose notes or solutions
+// contain it (termBucket), and each indexed log to its terms (searchDocBucket)
+// so a log's postings can be removed when it changes.
+var (
+	termBucket      = []byte("search_terms")
+	searchDocBucket = []byte("search_docs")
+)
+
+// SearchResult is a log matching a search query.
+type SearchResult struct {
+	Log     model.Log
+	Score   float64
+	Source  string // "notes" or the solution's file name
+	Snippet string // Text around the first match, on one line
+}
+
+var stopWords = map[string]bool{
+	"a": true, "an": true, "and": true, "are": true, "as": true, "at": true, "be": true, "by": true,
+	"for": true, "from": true, "in": true, "is": true, "it": true, "of": true, "on": true, "or": true,
+	"the": true, "to": true, "was": true, "with": true,
+}
+
+type token struct {
+	term       string
+	start, end int // Byte offsets in the original text
+}
+
+// tokenize splits text into lowercase words and numbers.
+func tokenize(text string) []token {
+	var tokens []token
+	start := -1
+	for i, r := range text + " " {
+		isWord := unicode.IsLetter(r) || unicode.IsDigit(r) || r == '_'
+		if isWord && start < 0 {
+			start = i
+		} else if !isWord && start >= 0 {
+			tokens = append(tokens, token{term: strings.ToLower(text[start:i]), start: start, end: i})
+			start = -1
+		}
+	}
+	return tokens
+}
+
+// Terms returns the searchable terms in text, skipping stop words.
+func Terms(text string) []string {
+	var terms []string
+	for _, t := range tokenize(text) {
+		if !stopWords[t.term] {
+			terms = append(terms, t.term)
+		}
+	}
+	return terms
+}
+
+// searchTexts returns the indexed texts of a log: its notes, then each
+// attached solution, labelled with where they came from.
+func searchTexts(tx *bbolt.Tx, logEntry model.Log) [][2]string {
+	texts := [][2]string{{"notes", logEntry.Notes}}
+	solutions, _ := solutionsOf(tx, logEntry.Date)
+	for _, s := range solutions {
+		texts = append(texts, [2]string{s.FileName, s.Code})
+	}
+	return texts
+}
+
+// indexLog replaces the index entries of the log stored under key.
+func indexLog(tx *bbolt.Tx, key []byte, logEntry model.Log) error {
+	if err := unindexLog(tx, key); err != nil {
+		return err
+	}
+	freq := make(map[string]int)
+	for _, text := range searchTexts(tx, logEntry) {
+		for _, term := range Terms(text[1]) {
+			freq[term]++
+		}
+	}
+	if len(freq) == 0 {
+		return nil
+	}
+
+	terms := make([]string, 0, len(freq))
+	tb := tx.Bucket(termBucket)
+	for term, n := range freq {
+		postings, err := readPostings(tb, term)
+		if err != nil {
+			return err
+		}
+		postings[string(key)] = n
+		if err := writePostings(tb, term, postings); err != nil {
+			return err
+		}
+		terms = append(terms, term)
+	}
+	encoded, err := json.Marshal(terms)
+	if err != nil {
+		return err
+	}
+	return tx.Bucket(searchDocBucket).Put(key, encoded)
+}
+
+// unindexLog removes the log stored under key from the index.
+func unindexLog(tx *bbolt.Tx, key []byte) error {
+	docs := tx.Bucket(searchDocBucket)
+	v := docs.Get(key)
+	if v == nil {
+		return nil
+	}
+	var terms []string
+	if err := json.Unmarshal(v, &terms); err != nil {
+		return err
+	}
+	tb := tx.Bucket(termBucket)
+	for _, term := range terms {
+		postings, err := readPostings(tb, term)
+		if err != nil {
+			return err
+		}
+		delete(postings, string(key))
+		if err := writePostings(tb, term, postings); err != nil {
+			return err
+		}
+	}
+	return docs.Delete(key)
+}
+
+// reindexLogAt re-indexes the log stored at key, e.g. after its solutions changed.
+func reindexLogAt(tx *bbolt.Tx, key []byte) error {
+	v := tx.Bucket(logBucket).Get(key)
+	if v == nil {
+		return unindexLog(tx, key)
+	}
+	var logEntry model.Log
+	if err := json.Unmarshal(v, &logEntry); err != nil {
+		return err
+	}
+	return indexLog(tx, key, logEntry)
+}
+
+func readPostings(b *bbolt.Bucket, term string) (map[string]int, error) {
+	postings := make(map[string]int)
+	if v := b.Get([]byte(term)); v != nil {
+		if err := json.Unmarshal(v, &postings); err != nil {
+			return nil, err
+		}
+	}
+	return postings, nil
+}
+
+func writePostings(b *bbolt.Bucket, term string, postings map[string]int) error {
+	if len(postings) == 0 {
+		return b.Delete([]byte(term))
+	}
+	encoded, err := json.Marshal(postings)
+	if err != nil {
+		return err
+	}
+	return b.Put([]byte(term), encoded)
+}
+
+// backfillSearchIndex indexes every log when the index is empty, so databases
+// from before search existed become searchable.
+func backfillSearchIndex(tx *bbolt.Tx) error {
+	if k, _ := tx.Bucket(searchDocBucket).Cursor().First(); k != nil {
+		return nil
+	}
+	return tx.Bucket(logBucket).ForEach(func(k, v []byte) error {
+		var logEntry model.Log
+		if err := json.Unmarshal(v, &logEntry); err != nil {
+			return nil
+		}
+		return indexLog(tx, k, logEntry)
+	})
+}
+
+// Search returns the logs whose notes or solutions contain every term of the
+// query, best match first. Scores are TF-IDF sums.
+func Search(query string) ([]SearchResult, error) {
+	terms := Terms(query)
+	if len(terms) == 0 {
+		return nil, nil
+	}
+	var results []SearchResult
+	err := db.View(func(tx *bbolt.Tx) error {
+		docs := tx.Bucket(searchDocBucket).Stats().KeyN
+		scores := make(map[string]float64)
+		for i, term := range terms {
+			postings, err := readPostings(tx.Bucket(termBucket), term)
+			if err != nil {
+				return err
+			}
+			idf := math.Log(1 + float64(docs)/float64(len(postings)+1))
+			for key, n := range postings {
+				if _, ok := scores[key]; ok || i == 0 {
+					scores[key] += (1 + math.Log(float64(n))) * idf
+				}
+			}
+			// Drop logs that lack this term.
+			for key := range scores {
+				if _, ok := postings[key]; !ok {
+					delete(scores, key)
+				}
+			}
+		}
+
+		lb := tx.Bucket(logBucket)
+		for key, score := range scores {
+			v := lb.Get([]byte(key))
+			if v == nil {
+				continue
+			}
+			var logEntry model.Log
+			if err := json.Unmarshal(v, &logEntry); err != nil {
+				continue
+			}
+			result := SearchResult{Log: logEntry, Score: score}
+			result.Source, result.Snippet = snippet(searchTexts(tx, logEntry), terms)
+			results = append(results, result)
+		}
+		return nil
+	})
+	sort.Slice(results, func(i, j int) bool {
+		if results[i].Score != results[j].Score {
+			return results[i].Score > results[j].Score
+		}
+		return results[i].Log.Date.After(results[j].Log.Date)
+	})
+	return results, err
+}
+
+// snippet picks the text with the most matching terms and returns a window
+// of it around the first match.
+func snippet(texts [][2]string, terms []string) (string, string) {
+	const before, after = 30, 60
+	wanted := make(map[string]bool, len(terms))
+	for _, t := range terms {
+		wanted[t] = true
+	}
+	best, bestHits, bestStart := -1, 0, 0
+	for i, text := range texts {
+		hits, first := make(map[string]bool), -1
+		for _, t := range tokenize(text[1]) {
+			if wanted[t.term] {
+				hits[t.term] = true
+				if first < 0 {
+					first = t.start
+				}
+			}
+		}
+		if len(hits) > bestHits {
+			best, bestHits, bestStart = i, len(hits), first
+		}
+	}
+	if best < 0 {
+		return "", ""
+	}
+
+	text := texts[best][1]
+	start, end := max(0, bestStart-before), min(len(text), bestStart+after)
+	for start > 0 && !utf8.RuneStart(text[start]) {
+		start--
+	}
+	for end < len(text) && !utf8.RuneStart(text[end]) {
+		end++
+	}
+	s := strings.Join(strings.Fields(text[start:end]), " ")
+	if start > 0 {
+		s = "…" + s
+	}
+	if end < len(text) {
+		s += "…"
+	}
+	return texts[best][0], s
+}
+
+// HighlightTerms wraps every word of text that is one of terms with mark.
+func HighlightTerms(text string, terms []string, mark func(string) string) string {
+	wanted := make(map[string]bool, len(terms))
+	for _, t := range terms {
+		wanted[t] = true
+	}
+	var b strings.Builder
+	last := 0
+	for _, t := range tokenize(text) {
+		if !wanted[t.term] {
+			continue
+		}
+		b.WriteString(text[last:t.start])
+		b.WriteString(mark(text[t.start:t.end]))
+		last = t.end
+	}
+	b.WriteString(text[last:])
+	return b.String()
+}
@@ -0,0 +1,218 @@
+package db
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/Harschmann/Todo-/model"
+	"go.etcd.io/bbolt"
+)
+
+// Solutions are stored by ID (solutionBucket) and indexed by the key of the
+// log they belong to (solutionLogBucket), so a log's solutions can be found
+// without reading every solution.
+var (
+	solutionBucket    = []byte("solutions")
+	solutionLogBucket = []byte("solutions_by_log")
+)
+
+func SaveSolution(s *model.Solution) error {
+	if s.Code == "" {
+		return fmt.Errorf("a solution needs some code")
+	}
+	return db.Update(func(tx *bbolt.Tx) error {
+		key, err := s.LogDate.MarshalText()
+		if err != nil {
+			return err
+		}
+		if tx.Bucket(logBucket).Get(key) == nil {
+			return fmt.Errorf("no log from %s to attach the solution to", s.LogDate.Format("2006-01-02 15:04"))
+		}
+		b := tx.Bucket(solutionBucket)
+		if s.ID == 0 {
+			id, err := b.NextSequence()
+			if err != nil {
+				return err
+			}
+			s.ID = int(id)
+		}
+		if s.Added.IsZero() {
+			s.Added = time.Now()
+		}
+		if err := putSolution(tx, *s); err != nil {
+			return err
+		}
+		return reindexLogAt(tx, key)
+	})
+}
+
+func GetSolution(id int) (model.Solution, error) {
+	var s model.Solution
+	err := db.View(func(tx *bbolt.Tx) error {
+		v := tx.Bucket(solutionBucket).Get(itob(id))
+		if v == nil {
+			return fmt.Errorf("no solution with ID %d", id)
+		}
+		return json.Unmarshal(v, &s)
+	})
+	return s, err
+}
+
+// GetSolutions returns the solutions attached to the log stored at logDate,
+// oldest first.
+func GetSolutions(logDate time.Time) ([]model.Solution, error) {
+	var solutions []model.Solution
+	err := db.View(func(tx *bbolt.Tx) error {
+		var err error
+		solutions, err = solutionsOf(tx, logDate)
+		return err
+	})
+	return solutions, err
+}
+
+func DeleteSolution(id int) error {
+	return db.Update(func(tx *bbolt.Tx) error {
+		s, err := removeSolution(tx, id)
+		if err != nil {
+			return err
+		}
+		key, err := s.LogDate.MarshalText()
+		if err != nil {
+			return err
+		}
+		return reindexLogAt(tx, key)
+	})
+}
+
+// solutionIndexKey is the solutionLogBucket key of a solution: the log's key,
+// a zero byte and the solution's ID, so a log's solutions share a prefix.
+func solutionIndexKey(logDate time.Time, id int) ([]byte, error) {
+	key, err := logDate.MarshalText()
+	if err != nil {
+		return nil, err
+	}
+	return append(append(key, 0), itob(id)...), nil
+}
+
+// solutionsOf returns the solutions attached to the log at logDate, oldest
+// first.
+func solutionsOf(tx *bbolt.Tx, logDate time.Time) ([]model.Solution, error) {
+	prefix, err := logDate.MarshalText()
+	if err != nil {
+		return nil, err
+	}
+	prefix = append(prefix, 0)
+	b := tx.Bucket(solutionBucket)
+	var solutions []model.Solution
+	c := tx.Bucket(solutionLogBucket).Cursor()
+	for k, _ := c.Seek(prefix); k != nil && bytes.HasPrefix(k, prefix); k, _ = c.Next() {
+		v := b.Get(k[len(prefix):])
+		if v == nil {
+			continue
+		}
+		var s model.Solution
+		if err := json.Unmarshal(v, &s); err != nil {
+			log.Printf("could not unmarshal solution: %v", err)
+			continue
+		}
+		solutions = append(solutions, s)
+	}
+	return solutions, nil
+}
+
+// putSolution stores a solution and indexes it under its log, replacing any
+// earlier version with the same ID.
+func putSolution(tx *bbolt.Tx, s model.Solution) error {
+	if tx.Bucket(solutionBucket).Get(itob(s.ID)) != nil {
+		if _, err := removeSolution(tx, s.ID); err != nil {
+			return err
+		}
+	}
+	encoded, err := json.Marshal(s)
+	if err != nil {
+		return err
+	}
+	if err := tx.Bucket(solutionBucket).Put(itob(s.ID), encoded); err != nil {
+		return err
+	}
+	indexKey, err := solutionIndexKey(s.LogDate, s.ID)
+	if err != nil {
+		return err
+	}
+	return tx.Bucket(solutionLogBucket).Put(indexKey, nil)
+}
+
+// removeSolution deletes a solution and its index entry, and returns it.
+func removeSolution(tx *bbolt.Tx, id int) (model.Solution, error) {
+	b := tx.Bucket(solutionBucket)
+	var s model.Solution
+	v := b.Get(itob(id))
+	if v == nil {
+		return s, fmt.Errorf("no solution with ID %d", id)
+	}
+	if err := json.Unmarshal(v, &s); err != nil {
+		return s, err
+	}
+	indexKey, err := solutionIndexKey(s.LogDate, s.ID)
+	if err != nil {
+		return s, err
+	}
+	if err := tx.Bucket(solutionLogBucket).Delete(indexKey); err != nil {
+		return s, err
+	}
+	return s, b.Delete(itob(id))
+}
+
+// deleteSolutions removes every solution attached to the log at logDate and
+// returns them.
+func deleteSolutions(tx *bbolt.Tx, logDate time.Time) ([]model.Solution, error) {
+	removed, err := solutionsOf(tx, logDate)
+	if err != nil {
+		return nil, err
+	}
+	for _, s := range removed {
+		if _, err := removeSolution(tx, s.ID); err != nil {
+			return nil, err
+		}
+	}
+	return removed, nil
+}
+
+// moveSolutions reattaches the solutions of the log at from to the log at to.
+func moveSolutions(tx *bbolt.Tx, from, to time.Time) error {
+	moved, err := solutionsOf(tx, from)
+	if err != nil {
+		return err
+	}
+	for _, s := range moved {
+		s.LogDate = to
+		if err := putSolution(tx, s); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// backfillSolutionIndex indexes every solution by its log when the index is
+// empty, so databases from before the index existed keep their solutions.
+func backfillSolutionIndex(tx *bbolt.Tx) error {
+	if k, _ := tx.Bucket(solutionLogBucket).Cursor().First(); k != nil {
+		return nil
+	}
+	index := tx.Bucket(solutionLogBucket)
+	return tx.Bucket(solutionBucket).ForEach(func(k, v []byte) error {
+		var s model.Solution
+		if err := json.Unmarshal(v, &s); err != nil {
+			log.Printf("could not unmarshal solution: %v", err)
+			return nil
+		}
+		indexKey, err := solutionIndexKey(s.LogDate, s.ID)
+		if err != nil {
+			return err
+		}
+		return index.Put(indexKey, nil)
+	})
+}
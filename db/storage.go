@@ -10,8 +10,9 @@ import (
 	"strings"
 	"time"
 
+	"github.com/Harschmann/Todo-/config"
 	"github.com/Harschmann/Todo-/model"
-	"github.com/xuri/excelize/v2"
+	"github.com/Harschmann/Todo-/utils"
 	"go.etcd.io/bbolt"
 )
 
@@ -24,6 +25,8 @@ type DailyStats struct {
 var db *bbolt.DB
 var logBucket = []byte("logs")
 
+var buckets = [][]byte{logBucket, goalBucket, reviewBucket, problemBucket, contestBucket, solutionBucket, solutionLogBucket, termBucket, searchDocBucket, trashBucket, revisionBucket}
+
 func Init(dbPath string) error {
 	var err error
 	db, err = bbolt.Open(dbPath, 0600, nil)
@@ -31,12 +34,19 @@ func Init(dbPath string) error {
 		return err
 	}
 	return db.Update(func(tx *bbolt.Tx) error {
-		_, err := tx.CreateBucketIfNotExists(logBucket)
-		if err != nil {
-			log.Printf("could not create bucket: %v", err)
+		for _, name := range buckets {
+			if _, err := tx.CreateBucketIfNotExists(name); err != nil {
+				log.Printf("could not create bucket %s: %v", name, err)
+				return err
+			}
+		}
+		if err := backfillProblems(tx); err != nil {
 			return err
 		}
-		return nil
+		if err := backfillSolutionIndex(tx); err != nil {
+			return err
+		}
+		return backfillSearchIndex(tx)
 	})
 }
 
@@ -52,11 +62,15 @@ func GetAppDataDir() (string, error) {
 	return appDataDir, nil
 }
 
+// SaveLog saves a new log under its Date, or now if it has none. If another
+// log already uses that exact time, the date is nudged forward.
 func SaveLog(logEntry *model.Log) error {
 	return db.Update(func(tx *bbolt.Tx) error {
 		b := tx.Bucket(logBucket)
-		logEntry.Date = time.Now()
-		key, err := logEntry.Date.MarshalText()
+		if logEntry.Date.IsZero() {
+			logEntry.Date = time.Now()
+		}
+		key, err := freeLogKey(b, logEntry)
 		if err != nil {
 			return err
 		}
@@ -64,10 +78,63 @@ func SaveLog(logEntry *model.Log) error {
 		if err != nil {
 			return err
 		}
-		return b.Put(key, encoded)
+		if err := ensureProblem(tx, logEntry); err != nil {
+			return err
+		}
+		if err := b.Put(key, encoded); err != nil {
+			return err
+		}
+		return indexLog(tx, key, *logEntry)
 	})
 }
 
+// ImportLogs saves logs with their own dates in a single transaction, along
+// with catalog details for their problems (problems[i] belongs to logs[i]).
+func ImportLogs(logs []model.Log, problems []model.Problem) error {
+	return db.Update(func(tx *bbolt.Tx) error {
+		b := tx.Bucket(logBucket)
+		for i := range logs {
+			logEntry := &logs[i]
+			key, err := freeLogKey(b, logEntry)
+			if err != nil {
+				return err
+			}
+			encoded, err := json.Marshal(logEntry)
+			if err != nil {
+				return err
+			}
+			if err := ensureProblem(tx, logEntry); err != nil {
+				return err
+			}
+			if err := mergeProblem(tx, logEntry.ProblemKey(), problems[i]); err != nil {
+				return err
+			}
+			if err := b.Put(key, encoded); err != nil {
+				return err
+			}
+			if err := indexLog(tx, key, *logEntry); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// freeLogKey returns the key for a log's date, nudging the date forward by a
+// nanosecond at a time if another log already uses it.
+func freeLogKey(b *bbolt.Bucket, logEntry *model.Log) ([]byte, error) {
+	for {
+		key, err := logEntry.Date.MarshalText()
+		if err != nil {
+			return nil, err
+		}
+		if b.Get(key) == nil {
+			return key, nil
+		}
+		logEntry.Date = logEntry.Date.Add(time.Nanosecond)
+	}
+}
+
 func GetAllLogs() ([]model.Log, error) {
 	var logs []model.Log
 	err := db.View(func(tx *bbolt.Tx) error {
@@ -89,36 +156,176 @@ func GetAllLogs() ([]model.Log, error) {
 	return logs, nil
 }
 
+// DeleteLog moves a log and its solutions to the trash, from where
+// RestoreLog can bring them back.
 func DeleteLog(date time.Time) error {
+	return DeleteLogs([]time.Time{date})
+}
+
+// DeleteLogs moves several logs to the trash in one transaction. They share
+// a deletion time, so undoing the last delete restores all of them.
+func DeleteLogs(dates []time.Time) error {
 	return db.Update(func(tx *bbolt.Tx) error {
-		b := tx.Bucket(logBucket)
-		key, err := date.MarshalText()
-		if err != nil {
-			return err
+		now := time.Now()
+		for _, date := range dates {
+			if _, err := trashLog(tx, date, now); err != nil {
+				return err
+			}
 		}
-		return b.Delete(key)
+		return nil
 	})
 }
 
+func trashLog(tx *bbolt.Tx, date time.Time, now time.Time) (model.TrashedLog, error) {
+	b := tx.Bucket(logBucket)
+	key, err := date.MarshalText()
+	if err != nil {
+		return model.TrashedLog{}, err
+	}
+	v := b.Get(key)
+	if v == nil {
+		return model.TrashedLog{}, fmt.Errorf("no log from %s", date.Format("2006-01-02 15:04"))
+	}
+	trashed := model.TrashedLog{Deleted: now}
+	if err := json.Unmarshal(v, &trashed.Log); err != nil {
+		return trashed, err
+	}
+	if trashed.Solutions, err = deleteSolutions(tx, date); err != nil {
+		return trashed, err
+	}
+	if err := unindexLog(tx, key); err != nil {
+		return trashed, err
+	}
+	if err := putTrash(tx, &trashed); err != nil {
+		return trashed, err
+	}
+	return trashed, b.Delete(key)
+}
+
 func UpdateLog(logEntry *model.Log) error {
+	return UpdateLogAt(logEntry.Date, logEntry)
+}
+
+// UpdateLogAt saves an edited version of the log stored at date. If the edit
+// changed the log's Date, the log moves there along with its solutions,
+// search entries and revision history.
+func UpdateLogAt(date time.Time, logEntry *model.Log) error {
 	return db.Update(func(tx *bbolt.Tx) error {
-		b := tx.Bucket(logBucket)
-		key, err := logEntry.Date.MarshalText()
-		if err != nil {
+		if err := recordRevisionAt(tx, date, *logEntry, time.Now()); err != nil {
 			return err
 		}
-		encoded, err := json.Marshal(logEntry)
-		if err != nil {
-			return err
+		return putLogAt(tx, date, logEntry)
+	})
+}
+
+// UpdateLogs saves changes to several logs in one transaction.
+func UpdateLogs(logs []model.Log) error {
+	return db.Update(func(tx *bbolt.Tx) error {
+		now := time.Now()
+		for i := range logs {
+			if err := updateLog(tx, &logs[i], now); err != nil {
+				return err
+			}
 		}
-		return b.Put(key, encoded)
+		return nil
 	})
 }
 
-// CORRECTED: Add the necessary stats functions back in.
-func normalizeDate(t time.Time) time.Time {
-	year, month, day := t.Date()
-	return time.Date(year, month, day, 0, 0, 0, 0, t.Location())
+// SetCalendarEventIDs saves the calendar event ID of each log in one
+// transaction, leaving the rest of the stored log and its history untouched.
+func SetCalendarEventIDs(logs []model.Log) error {
+	return db.Update(func(tx *bbolt.Tx) error {
+		b := tx.Bucket(logBucket)
+		for _, l := range logs {
+			key, err := l.Date.MarshalText()
+			if err != nil {
+				return err
+			}
+			v := b.Get(key)
+			if v == nil {
+				return fmt.Errorf("no log from %s", l.Date.Format("2006-01-02 15:04"))
+			}
+			var stored model.Log
+			if err := json.Unmarshal(v, &stored); err != nil {
+				return err
+			}
+			if stored.CalendarEventID == l.CalendarEventID {
+				continue
+			}
+			stored.CalendarEventID = l.CalendarEventID
+			encoded, err := json.Marshal(stored)
+			if err != nil {
+				return err
+			}
+			if err := b.Put(key, encoded); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func updateLog(tx *bbolt.Tx, logEntry *model.Log, now time.Time) error {
+	if err := recordRevisionAt(tx, logEntry.Date, *logEntry, now); err != nil {
+		return err
+	}
+	return putLogAt(tx, logEntry.Date, logEntry)
+}
+
+// putLogAt stores logEntry in place of the log stored at date, first moving
+// everything keyed by that date if logEntry.Date differs.
+func putLogAt(tx *bbolt.Tx, date time.Time, logEntry *model.Log) error {
+	b := tx.Bucket(logBucket)
+	key, err := logEntry.Date.MarshalText()
+	if err != nil {
+		return err
+	}
+	if !logEntry.Date.Equal(date) {
+		if key, err = moveLog(tx, date, logEntry); err != nil {
+			return err
+		}
+	}
+	encoded, err := json.Marshal(logEntry)
+	if err != nil {
+		return err
+	}
+	if err := ensureProblem(tx, logEntry); err != nil {
+		return err
+	}
+	if err := b.Put(key, encoded); err != nil {
+		return err
+	}
+	return indexLog(tx, key, *logEntry)
+}
+
+// moveLog frees the log stored at date for logEntry's new date and points its
+// solutions and revisions there. It returns the new key, which may nudge
+// logEntry.Date if another log already uses that time.
+func moveLog(tx *bbolt.Tx, date time.Time, logEntry *model.Log) ([]byte, error) {
+	b := tx.Bucket(logBucket)
+	oldKey, err := date.MarshalText()
+	if err != nil {
+		return nil, err
+	}
+	if err := unindexLog(tx, oldKey); err != nil {
+		return nil, err
+	}
+	if err := b.Delete(oldKey); err != nil {
+		return nil, err
+	}
+	key, err := freeLogKey(b, logEntry)
+	if err != nil {
+		return nil, err
+	}
+	if err := moveSolutions(tx, date, logEntry.Date); err != nil {
+		return nil, err
+	}
+	return key, moveRevisions(tx, date, logEntry.Date)
+}
+
+// counts reports whether a log counts as a solved problem under the current config.
+func counts(logEntry model.Log) bool {
+	return config.Get().Counts(logEntry.EffectiveStatus())
 }
 
 func calculateStreak(logs []model.Log) int {
@@ -127,10 +334,12 @@ func calculateStreak(logs []model.Log) int {
 	}
 	uniqueDates := make(map[time.Time]bool)
 	for _, logEntry := range logs {
-		uniqueDates[normalizeDate(logEntry.Date)] = true
+		if counts(logEntry) {
+			uniqueDates[utils.StartOfDay(logEntry.Date)] = true
+		}
 	}
 	streak := 0
-	dayToCheck := normalizeDate(time.Now())
+	dayToCheck := utils.StartOfDay(time.Now())
 	if !uniqueDates[dayToCheck] {
 		dayToCheck = dayToCheck.AddDate(0, 0, -1)
 	}
@@ -147,11 +356,13 @@ func GetDailyStats() (DailyStats, error) {
 	if err != nil {
 		return stats, err
 	}
-	now := time.Now()
-	startOfDay := normalizeDate(now)
+	startOfDay := utils.StartOfDay(time.Now())
+	endOfDay := startOfDay.AddDate(0, 0, 1)
 	for _, logEntry := range allLogs {
-		if logEntry.Date.After(startOfDay) {
-			stats.SolvedToday++
+		if !logEntry.Date.Before(startOfDay) && logEntry.Date.Before(endOfDay) {
+			if counts(logEntry) {
+				stats.SolvedToday++
+			}
 			stats.TimeToday += logEntry.TimeSpent
 		}
 	}
@@ -159,7 +370,7 @@ func GetDailyStats() (DailyStats, error) {
 	return stats, nil
 }
 
-// ... (Backup and Export functions remain the same)
+// ... (Backup functions remain the same)
 func BackupToJSON(appDataDir string) error {
 	logs, err := GetAllLogs()
 	if err != nil {
@@ -203,45 +414,3 @@ func BackupToJSON(appDataDir string) error {
 	}
 	return nil
 }
-func ExportToExcel() (string, error) {
-	logs, err := GetAllLogs()
-	if err != nil {
-		return "", fmt.Errorf("could not get logs for export: %w", err)
-	}
-
-	f := excelize.NewFile()
-	sheet := "Logs"
-	index, _ := f.NewSheet(sheet)
-	headers := []string{"Date", "Platform", "Question ID", "Topic", "Difficulty", "Time Spent (mins)", "Notes"}
-	for i, header := range headers {
-		cell, _ := excelize.CoordinatesToCellName(i+1, 1)
-		f.SetCellValue(sheet, cell, header)
-	}
-	for i, logEntry := range logs {
-		row := i + 2
-		f.SetCellValue(sheet, fmt.Sprintf("A%d", row), logEntry.Date.Format("2006-01-02"))
-		f.SetCellValue(sheet, fmt.Sprintf("B%d", row), logEntry.Platform)
-		f.SetCellValue(sheet, fmt.Sprintf("C%d", row), logEntry.QuestionID)
-		f.SetCellValue(sheet, fmt.Sprintf("D%d", row), logEntry.Topic)
-		f.SetCellValue(sheet, fmt.Sprintf("E%d", row), logEntry.Difficulty)
-		f.SetCellValue(sheet, fmt.Sprintf("F%d", row), logEntry.TimeSpent)
-		f.SetCellValue(sheet, fmt.Sprintf("G%d", row), logEntry.Notes)
-	}
-	f.SetActiveSheet(index)
-
-	// Get the path to the user's home directory.
-	homeDir, err := os.UserHomeDir()
-	if err != nil {
-		return "", err
-	}
-
-	// Construct the full path to the Desktop.
-	desktopPath := filepath.Join(homeDir, "Desktop")
-	fileName := "todoplusplus_logs_export.xlsx"
-	fullPath := filepath.Join(desktopPath, fileName)
-
-	if err := f.SaveAs(fullPath); err != nil {
-		return "", err
-	}
-	return fullPath, nil
-}
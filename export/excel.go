@@ -0,0 +1,139 @@
+package export
+
+import (
+	"fmt"
+	"io"
+	"math"
+
+	"github.com/Harschmann/Todo-/model"
+	"github.com/xuri/excelize/v2"
+)
+
+// Excel writes a workbook with a filterable "Logs" table, pivot-style summary
+// sheets by topic, difficulty and month, and a chart of problems per week.
+type Excel struct{}
+
+func (Excel) Extension() string { return "xlsx" }
+
+const logsSheet = "Logs"
+
+func (Excel) Export(w io.Writer, logs []model.Log) error {
+	f := excelize.NewFile()
+	defer f.Close()
+	if err := f.SetSheetName("Sheet1", logsSheet); err != nil {
+		return err
+	}
+	if err := writeLogsSheet(f, logs); err != nil {
+		return err
+	}
+	summaries := []struct {
+		sheet, header string
+		key           func(model.Log) string
+	}{
+		{"By Topic", "Topic", ByTopic},
+		{"By Difficulty", "Difficulty", ByDifficulty},
+		{"By Month", "Month", ByMonth},
+	}
+	for _, s := range summaries {
+		if err := writeSummarySheet(f, s.sheet, s.header, Summarize(logs, s.key)); err != nil {
+			return err
+		}
+	}
+	if err := writeWeeklySheet(f, WeeklyCounts(logs)); err != nil {
+		return err
+	}
+	f.SetActiveSheet(0)
+	return f.Write(w)
+}
+
+// writeLogsSheet writes one row per log as an Excel table with autofilter,
+// real date cells and a frozen header row.
+func writeLogsSheet(f *excelize.File, logs []model.Log) error {
+	sheet := logsSheet
+	dateFmt := "yyyy-mm-dd hh:mm"
+	dateStyle, err := f.NewStyle(&excelize.Style{CustomNumFmt: &dateFmt})
+	if err != nil {
+		return err
+	}
+	for i, header := range Headers {
+		cell, _ := excelize.CoordinatesToCellName(i+1, 1)
+		f.SetCellValue(sheet, cell, header)
+	}
+	for i, logEntry := range logs {
+		row := i + 2
+		f.SetCellValue(sheet, fmt.Sprintf("A%d", row), logEntry.Date)
+		f.SetCellStyle(sheet, fmt.Sprintf("A%d", row), fmt.Sprintf("A%d", row), dateStyle)
+		f.SetCellValue(sheet, fmt.Sprintf("B%d", row), logEntry.Platform)
+		f.SetCellValue(sheet, fmt.Sprintf("C%d", row), logEntry.QuestionID)
+		f.SetCellValue(sheet, fmt.Sprintf("D%d", row), logEntry.Topic)
+		f.SetCellValue(sheet, fmt.Sprintf("E%d", row), logEntry.Difficulty)
+		f.SetCellValue(sheet, fmt.Sprintf("F%d", row), logEntry.EffectiveStatus())
+		f.SetCellValue(sheet, fmt.Sprintf("G%d", row), logEntry.TimeSpent)
+		f.SetCellValue(sheet, fmt.Sprintf("H%d", row), logEntry.Notes)
+	}
+
+	f.SetColWidth(sheet, "A", "A", 18)
+	f.SetColWidth(sheet, "B", "F", 14)
+	f.SetColWidth(sheet, "G", "G", 18)
+	f.SetColWidth(sheet, "H", "H", 60)
+	if err := f.SetPanes(sheet, &excelize.Panes{Freeze: true, YSplit: 1, TopLeftCell: "A2", ActivePane: "bottomLeft"}); err != nil {
+		return err
+	}
+	// A table needs at least one data row.
+	if len(logs) == 0 {
+		return nil
+	}
+	lastCell, _ := excelize.CoordinatesToCellName(len(Headers), len(logs)+1)
+	return f.AddTable(sheet, &excelize.Table{
+		Range:          "A1:" + lastCell,
+		Name:           "LogsTable",
+		StyleName:      "TableStyleMedium2",
+		ShowRowStripes: boolPtr(true),
+	})
+}
+
+func writeSummarySheet(f *excelize.File, sheet, keyHeader string, rows []SummaryRow) error {
+	if _, err := f.NewSheet(sheet); err != nil {
+		return err
+	}
+	f.SetSheetRow(sheet, "A1", &[]interface{}{keyHeader, "Logs", "Solved", "Total Time (mins)", "Avg Time (mins)"})
+	for i, r := range rows {
+		cell := fmt.Sprintf("A%d", i+2)
+		f.SetSheetRow(sheet, cell, &[]interface{}{r.Key, r.Logs, r.Solved, r.Minutes, math.Round(r.AvgMinutes()*10) / 10})
+	}
+	f.SetColWidth(sheet, "A", "E", 18)
+	return f.SetPanes(sheet, &excelize.Panes{Freeze: true, YSplit: 1, TopLeftCell: "A2", ActivePane: "bottomLeft"})
+}
+
+// writeWeeklySheet lists problems per week and charts them.
+func writeWeeklySheet(f *excelize.File, weeks []WeekCount) error {
+	sheet := "Per Week"
+	if _, err := f.NewSheet(sheet); err != nil {
+		return err
+	}
+	f.SetSheetRow(sheet, "A1", &[]interface{}{"Week Of", "Problems"})
+	for i, w := range weeks {
+		f.SetSheetRow(sheet, fmt.Sprintf("A%d", i+2), &[]interface{}{w.Start.Format("2006-01-02"), w.Count})
+	}
+	f.SetColWidth(sheet, "A", "B", 14)
+	if len(weeks) == 0 {
+		return nil
+	}
+	last := len(weeks) + 1
+	return f.AddChart(sheet, "D2", &excelize.Chart{
+		Type: excelize.Col,
+		Series: []excelize.ChartSeries{{
+			Name:       fmt.Sprintf("'%s'!$B$1", sheet),
+			Categories: fmt.Sprintf("'%s'!$A$2:$A$%d", sheet, last),
+			Values:     fmt.Sprintf("'%s'!$B$2:$B$%d", sheet, last),
+		}},
+		Title:  []excelize.RichTextRun{{Text: "Problems per Week"}},
+		Legend: excelize.ChartLegend{Position: "none"},
+		Dimension: excelize.ChartDimension{
+			Width:  720,
+			Height: 360,
+		},
+	})
+}
+
+func boolPtr(b bool) *bool { return &b }
@@ -0,0 +1,197 @@
+package export
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Harschmann/Todo-/db"
+	"github.com/Harschmann/Todo-/model"
+	"github.com/Harschmann/Todo-/query"
+)
+
+// Exporter writes logs in one file format.
+type Exporter interface {
+	// Extension is the file extension without the dot, e.g. "csv".
+	Extension() string
+	Export(w io.Writer, logs []model.Log) error
+}
+
+// Exporters maps each --format name to its exporter.
+var Exporters = map[string]Exporter{
+	"xlsx": Excel{},
+	"csv":  CSV{},
+	"json": JSON{},
+	"md":   Markdown{},
+	"html": HTML{},
+	"ics":  ICS{},
+}
+
+var formatAliases = map[string]string{
+	"excel":    "xlsx",
+	"markdown": "md",
+	"report":   "html",
+	"ical":     "ics",
+	"calendar": "ics",
+}
+
+// Headers are the column names shared by the tabular formats. The spreadsheet
+// importer recognizes them, so exports can be imported again.
+var Headers = []string{"Date", "Platform", "Question ID", "Topic", "Difficulty", "Status", "Time Spent (mins)", "Notes"}
+
+// record returns a log's values in the order of Headers. The date keeps its
+// time of day, so importing an export again keeps attempts on one day apart.
+func record(l model.Log) []string {
+	return []string{
+		l.Date.Format("2006-01-02 15:04"), l.Platform, l.QuestionID, l.Topic, l.Difficulty,
+		l.EffectiveStatus(), strconv.Itoa(l.TimeSpent), l.Notes,
+	}
+}
+
+// Get returns the exporter for a format name such as "csv" or "markdown".
+func Get(format string) (Exporter, error) {
+	format = strings.ToLower(strings.TrimPrefix(format, "."))
+	if alias, ok := formatAliases[format]; ok {
+		format = alias
+	}
+	exporter, ok := Exporters[format]
+	if !ok {
+		return nil, fmt.Errorf("unknown export format %q (available: %s)", format, strings.Join(Formats(), ", "))
+	}
+	return exporter, nil
+}
+
+// Formats lists the supported format names.
+func Formats() []string {
+	formats := make([]string, 0, len(Exporters))
+	for name := range Exporters {
+		formats = append(formats, name)
+	}
+	sort.Strings(formats)
+	return formats
+}
+
+// Filter selects which logs to export. Zero fields match everything.
+type Filter struct {
+	From       time.Time // Inclusive
+	To         time.Time // Exclusive
+	Platform   string
+	Topic      string
+	Difficulty string
+	Query      query.Query
+}
+
+func (f Filter) Match(l model.Log) bool {
+	if !f.Query.Match(l) {
+		return false
+	}
+	if !f.From.IsZero() && l.Date.Before(f.From) {
+		return false
+	}
+	if !f.To.IsZero() && !l.Date.Before(f.To) {
+		return false
+	}
+	if f.Platform != "" && !strings.EqualFold(l.Platform, f.Platform) {
+		return false
+	}
+	if f.Topic != "" && !strings.EqualFold(l.Topic, f.Topic) {
+		return false
+	}
+	if f.Difficulty != "" && !strings.EqualFold(l.Difficulty, f.Difficulty) {
+		return false
+	}
+	return true
+}
+
+// Options controls what is exported and where it is written.
+type Options struct {
+	Format string
+	// Output is a file path, a directory, "-" for stdout, or empty for a
+	// timestamped file on the Desktop (or the current directory if there is none).
+	Output string
+	Filter Filter
+}
+
+// Stdout is the Output value that writes the export to standard output.
+const Stdout = "-"
+
+// FilteredLogs returns every log matching the filter, oldest first.
+func FilteredLogs(filter Filter) ([]model.Log, error) {
+	logs, err := db.GetAllLogs()
+	if err != nil {
+		return nil, fmt.Errorf("could not get logs for export: %w", err)
+	}
+	var matched []model.Log
+	for _, l := range logs {
+		if filter.Match(l) {
+			matched = append(matched, l)
+		}
+	}
+	return matched, nil
+}
+
+// defaultFileName returns a timestamped name so exports never overwrite each other.
+func defaultFileName(extension string) string {
+	return fmt.Sprintf("todoplusplus_logs_%s.%s", time.Now().Format("2006-01-02_15-04-05"), extension)
+}
+
+// defaultDir is the Desktop if it exists, otherwise the current directory.
+func defaultDir() (string, error) {
+	if homeDir, err := os.UserHomeDir(); err == nil {
+		desktop := filepath.Join(homeDir, "Desktop")
+		if info, err := os.Stat(desktop); err == nil && info.IsDir() {
+			return desktop, nil
+		}
+	}
+	return os.Getwd()
+}
+
+// outputPath resolves opts.Output to the file to write.
+func outputPath(output, extension string) (string, error) {
+	if output == "" {
+		dir, err := defaultDir()
+		if err != nil {
+			return "", err
+		}
+		return filepath.Join(dir, defaultFileName(extension)), nil
+	}
+	if info, err := os.Stat(output); err == nil && info.IsDir() {
+		return filepath.Join(output, defaultFileName(extension)), nil
+	}
+	return output, nil
+}
+
+// Run exports the logs matching opts.Filter and returns where they were
+// written ("-" for stdout).
+func Run(opts Options) (string, error) {
+	exporter, err := Get(opts.Format)
+	if err != nil {
+		return "", err
+	}
+	logs, err := FilteredLogs(opts.Filter)
+	if err != nil {
+		return "", err
+	}
+	if opts.Output == Stdout {
+		return Stdout, exporter.Export(os.Stdout, logs)
+	}
+
+	fullPath, err := outputPath(opts.Output, exporter.Extension())
+	if err != nil {
+		return "", err
+	}
+	f, err := os.Create(fullPath)
+	if err != nil {
+		return "", err
+	}
+	if err := exporter.Export(f, logs); err != nil {
+		f.Close()
+		return "", err
+	}
+	return fullPath, f.Close()
+}
@@ -0,0 +1,78 @@
+package export
+
+import (
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/Harschmann/Todo-/model"
+	"github.com/Harschmann/Todo-/query"
+)
+
+var testLogs = []model.Log{
+	{Date: time.Date(2026, 3, 11, 20, 15, 0, 0, time.Local), Platform: "Codeforces", QuestionID: "1337A", Topic: "DP", Difficulty: "1600", Status: model.StatusAttempted, TimeSpent: 45, Notes: "tried | dp,\nwrong idea"},
+	{Date: time.Date(2026, 3, 11, 21, 30, 0, 0, time.Local), Platform: "LeetCode", QuestionID: "two-sum", Topic: "Data Structures", Difficulty: "Easy", TimeSpent: 10},
+}
+
+func TestRecord(t *testing.T) {
+	want := []string{"2026-03-11 20:15", "Codeforces", "1337A", "DP", "1600", "Attempted", "45", "tried | dp,\nwrong idea"}
+	if got := record(testLogs[0]); !slices.Equal(got, want) {
+		t.Errorf("record = %q, want %q", got, want)
+	}
+	// An empty status is exported as the status it stands for.
+	if got := record(testLogs[1])[5]; got != model.StatusAccepted {
+		t.Errorf("status = %q, want %q", got, model.StatusAccepted)
+	}
+	if len(record(testLogs[0])) != len(Headers) {
+		t.Errorf("record has %d values for %d headers", len(record(testLogs[0])), len(Headers))
+	}
+}
+
+func TestGet(t *testing.T) {
+	tests := []struct {
+		format, want string
+	}{
+		{"csv", "csv"},
+		{".CSV", "csv"},
+		{"excel", "xlsx"},
+		{"markdown", "md"},
+		{"ical", "ics"},
+		{"report", "html"},
+	}
+	for _, tt := range tests {
+		exporter, err := Get(tt.format)
+		if err != nil || exporter.Extension() != tt.want {
+			t.Errorf("Get(%q) = %v, %v, want the %s exporter", tt.format, exporter, err, tt.want)
+		}
+	}
+	if _, err := Get("pdf"); err == nil {
+		t.Error("Get(pdf) succeeded, want an error")
+	}
+}
+
+func TestFilterMatch(t *testing.T) {
+	dp := testLogs[0]
+	q, err := query.Parse("time>30")
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name   string
+		filter Filter
+		want   bool
+	}{
+		{"zero filter", Filter{}, true},
+		{"from is inclusive", Filter{From: dp.Date}, true},
+		{"to is exclusive", Filter{To: dp.Date}, false},
+		{"inside the range", Filter{From: dp.Date.AddDate(0, 0, -1), To: dp.Date.AddDate(0, 0, 1)}, true},
+		{"platform ignores case", Filter{Platform: "codeforces"}, true},
+		{"other topic", Filter{Topic: "Greedy"}, false},
+		{"difficulty", Filter{Difficulty: "1600"}, true},
+		{"query", Filter{Query: q}, true},
+	}
+	for _, tt := range tests {
+		if got := tt.filter.Match(dp); got != tt.want {
+			t.Errorf("%s: Match = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,303 @@
+package export
+
+import (
+	"fmt"
+	"html/template"
+	"io"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/Harschmann/Todo-/config"
+	"github.com/Harschmann/Todo-/model"
+	"github.com/Harschmann/Todo-/utils"
+)
+
+// HTML writes a self-contained progress report with inline SVG charts, meant
+// to be opened offline or shared as a single file.
+type HTML struct{}
+
+func (HTML) Extension() string { return "html" }
+
+const recentLogCount = 20
+
+var chartPalette = []string{"#4e79a7", "#f28e2b", "#e15759", "#76b7b2", "#59a14f", "#edc948", "#b07aa1", "#ff9da7", "#9c755f", "#bab0ac"}
+
+type reportData struct {
+	Generated     string
+	Total         int
+	Solved        int
+	Hours         string
+	CurrentStreak int
+	LongestStreak int
+	Heatmap       template.HTML
+	Topics        template.HTML
+	Difficulty    template.HTML
+	Streaks       template.HTML
+	Recent        []model.Log
+}
+
+func (HTML) Export(w io.Writer, logs []model.Log) error {
+	now := time.Now()
+	solvedDays := make(map[time.Time]bool)
+	data := reportData{Generated: now.Format("2006-01-02 15:04"), Total: len(logs)}
+	minutes := 0
+	for _, l := range logs {
+		minutes += l.TimeSpent
+		if config.Get().Counts(l.EffectiveStatus()) {
+			data.Solved++
+			solvedDays[utils.StartOfDay(l.Date)] = true
+		}
+	}
+	data.Hours = fmt.Sprintf("%.1f", float64(minutes)/60)
+
+	streaks := streakHistory(logs, solvedDays, now)
+	if len(streaks) > 0 {
+		data.CurrentStreak = streaks[len(streaks)-1].length
+		// A streak is still alive until the end of today.
+		if data.CurrentStreak == 0 && len(streaks) > 1 {
+			data.CurrentStreak = streaks[len(streaks)-2].length
+		}
+	}
+	for _, s := range streaks {
+		data.LongestStreak = max(data.LongestStreak, s.length)
+	}
+
+	data.Heatmap = heatmapSVG(logs, now)
+	data.Topics = topicBarsSVG(Summarize(logs, ByTopic))
+	data.Difficulty = difficultyColumnsSVG(logs)
+	data.Streaks = streakLineSVG(streaks)
+	for i := len(logs) - 1; i >= 0 && len(data.Recent) < recentLogCount; i-- {
+		data.Recent = append(data.Recent, logs[i])
+	}
+	return reportTemplate.Execute(w, data)
+}
+
+// heatmapSVG draws a calendar of the last 53 weeks, one square per day.
+func heatmapSVG(logs []model.Log, now time.Time) template.HTML {
+	const cell, gap, left, top = 11, 2, 28, 16
+	counts := make(map[time.Time]int)
+	maxCount := 0
+	for _, l := range logs {
+		d := utils.StartOfDay(l.Date)
+		counts[d]++
+		maxCount = max(maxCount, counts[d])
+	}
+	today := utils.StartOfDay(now)
+	start := utils.StartOfWeek(today).AddDate(0, 0, -52*7)
+	levels := []string{"#ebedf0", "#9be9a8", "#40c463", "#30a14e", "#216e39"}
+
+	var b strings.Builder
+	width := left + 53*(cell+gap)
+	height := top + 7*(cell+gap)
+	fmt.Fprintf(&b, `<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" font-size="9" fill="#57606a">`, width, height)
+	for i, name := range []string{"Mon", "", "Wed", "", "Fri", "", ""} {
+		if name != "" {
+			fmt.Fprintf(&b, `<text x="0" y="%d">%s</text>`, top+i*(cell+gap)+cell-1, name)
+		}
+	}
+	for d := start; !d.After(today); d = d.AddDate(0, 0, 1) {
+		days := int(d.Sub(start).Hours()/24 + 0.5)
+		col, row := days/7, (int(d.Weekday())+6)%7
+		if d.Day() == 1 {
+			fmt.Fprintf(&b, `<text x="%d" y="10">%s</text>`, left+col*(cell+gap), d.Format("Jan"))
+		}
+		level := 0
+		if n := counts[d]; n > 0 {
+			level = 1 + (n-1)*(len(levels)-1)/max(maxCount, 1)
+			level = min(level, len(levels)-1)
+		}
+		fmt.Fprintf(&b, `<rect x="%d" y="%d" width="%d" height="%d" rx="2" fill="%s"><title>%s: %d logs</title></rect>`,
+			left+col*(cell+gap), top+row*(cell+gap), cell, cell, levels[level], d.Format("2006-01-02"), counts[d])
+	}
+	b.WriteString(`</svg>`)
+	return template.HTML(b.String())
+}
+
+// topicBarsSVG draws a horizontal bar per topic, largest first.
+func topicBarsSVG(rows []SummaryRow) template.HTML {
+	if len(rows) == 0 {
+		return ""
+	}
+	sort.SliceStable(rows, func(i, j int) bool { return rows[i].Logs > rows[j].Logs })
+	const barHeight, gap, labelWidth, barWidth = 18, 6, 130, 360
+	var b strings.Builder
+	fmt.Fprintf(&b, `<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" font-size="12">`, labelWidth+barWidth+50, len(rows)*(barHeight+gap))
+	for i, r := range rows {
+		y := i * (barHeight + gap)
+		w := r.Logs * barWidth / rows[0].Logs
+		fmt.Fprintf(&b, `<text x="%d" y="%d" text-anchor="end">%s</text>`, labelWidth-8, y+barHeight-5, template.HTMLEscapeString(r.Key))
+		fmt.Fprintf(&b, `<rect x="%d" y="%d" width="%d" height="%d" rx="3" fill="%s"><title>%s: %d logs, %d mins</title></rect>`,
+			labelWidth, y, max(w, 1), barHeight, chartPalette[i%len(chartPalette)], template.HTMLEscapeString(r.Key), r.Logs, r.Minutes)
+		fmt.Fprintf(&b, `<text x="%d" y="%d">%d</text>`, labelWidth+max(w, 1)+6, y+barHeight-5, r.Logs)
+	}
+	b.WriteString(`</svg>`)
+	return template.HTML(b.String())
+}
+
+// difficultyColumnsSVG draws one stacked column per month, split by difficulty.
+func difficultyColumnsSVG(logs []model.Log) template.HTML {
+	months := Summarize(logs, ByMonth)
+	if len(months) == 0 {
+		return ""
+	}
+	var difficulties []string
+	colors := make(map[string]string)
+	counts := make(map[string]map[string]int)
+	for _, l := range logs {
+		if _, ok := colors[l.Difficulty]; !ok {
+			colors[l.Difficulty] = ""
+			difficulties = append(difficulties, l.Difficulty)
+		}
+		m := ByMonth(l)
+		if counts[m] == nil {
+			counts[m] = make(map[string]int)
+		}
+		counts[m][l.Difficulty]++
+	}
+	sort.Strings(difficulties)
+	for i, d := range difficulties {
+		colors[d] = chartPalette[i%len(chartPalette)]
+	}
+	maxLogs := 0
+	for _, m := range months {
+		maxLogs = max(maxLogs, m.Logs)
+	}
+
+	const colWidth, gap, chartHeight, bottom = 28, 8, 200, 40
+	legendWidth := 140
+	width := len(months)*(colWidth+gap) + legendWidth
+	var b strings.Builder
+	fmt.Fprintf(&b, `<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" font-size="10">`, width, max(chartHeight+bottom, len(difficulties)*16))
+	for i, m := range months {
+		x := i * (colWidth + gap)
+		y := chartHeight
+		for _, d := range difficulties {
+			n := counts[m.Key][d]
+			if n == 0 {
+				continue
+			}
+			h := n * chartHeight / maxLogs
+			y -= h
+			fmt.Fprintf(&b, `<rect x="%d" y="%d" width="%d" height="%d" fill="%s"><title>%s %s: %d</title></rect>`,
+				x, y, colWidth, h, colors[d], m.Key, template.HTMLEscapeString(d), n)
+		}
+		fmt.Fprintf(&b, `<text x="%d" y="%d" transform="rotate(45 %d %d)">%s</text>`, x+4, chartHeight+12, x+4, chartHeight+12, m.Key)
+	}
+	legendX := len(months)*(colWidth+gap) + 10
+	for i, d := range difficulties {
+		fmt.Fprintf(&b, `<rect x="%d" y="%d" width="10" height="10" fill="%s"/><text x="%d" y="%d">%s</text>`,
+			legendX, i*16, colors[d], legendX+14, i*16+9, template.HTMLEscapeString(d))
+	}
+	b.WriteString(`</svg>`)
+	return template.HTML(b.String())
+}
+
+type streakDay struct {
+	day    time.Time
+	length int
+}
+
+// streakHistory returns the streak length at the end of every day from the
+// first log until today.
+func streakHistory(logs []model.Log, solvedDays map[time.Time]bool, now time.Time) []streakDay {
+	if len(logs) == 0 {
+		return nil
+	}
+	first := utils.StartOfDay(logs[0].Date)
+	for _, l := range logs {
+		if d := utils.StartOfDay(l.Date); d.Before(first) {
+			first = d
+		}
+	}
+	var history []streakDay
+	length := 0
+	for d := first; !d.After(utils.StartOfDay(now)); d = d.AddDate(0, 0, 1) {
+		if solvedDays[d] {
+			length++
+		} else {
+			length = 0
+		}
+		history = append(history, streakDay{day: d, length: length})
+	}
+	return history
+}
+
+// streakLineSVG plots the streak length over (at most) the last year.
+func streakLineSVG(history []streakDay) template.HTML {
+	if len(history) > 365 {
+		history = history[len(history)-365:]
+	}
+	if len(history) == 0 {
+		return ""
+	}
+	const width, height, pad = 720, 160, 20
+	longest := 1
+	for _, s := range history {
+		longest = max(longest, s.length)
+	}
+	step := float64(width-2*pad) / float64(max(len(history)-1, 1))
+	var points []string
+	for i, s := range history {
+		x := float64(pad) + float64(i)*step
+		y := float64(height-pad) - float64(s.length)*float64(height-2*pad)/float64(longest)
+		points = append(points, fmt.Sprintf("%.1f,%.1f", x, y))
+	}
+	var b strings.Builder
+	fmt.Fprintf(&b, `<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" font-size="10" fill="#57606a">`, width, height)
+	fmt.Fprintf(&b, `<line x1="%d" y1="%d" x2="%d" y2="%d" stroke="#d0d7de"/>`, pad, height-pad, width-pad, height-pad)
+	fmt.Fprintf(&b, `<polyline fill="none" stroke="#e15759" stroke-width="2" points="%s"/>`, strings.Join(points, " "))
+	fmt.Fprintf(&b, `<text x="0" y="%d">%d</text>`, pad, longest)
+	fmt.Fprintf(&b, `<text x="%d" y="%d">%s</text>`, pad, height-4, history[0].day.Format("2006-01-02"))
+	fmt.Fprintf(&b, `<text x="%d" y="%d" text-anchor="end">%s</text>`, width-pad, height-4, history[len(history)-1].day.Format("2006-01-02"))
+	b.WriteString(`</svg>`)
+	return template.HTML(b.String())
+}
+
+var reportTemplate = template.Must(template.New("report").Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<title>todoplusplus progress report</title>
+<style>
+  body { font-family: -apple-system, "Segoe UI", Helvetica, Arial, sans-serif; color: #24292f; max-width: 980px; margin: 2em auto; padding: 0 1em; }
+  h1 { margin-bottom: 0; }
+  .generated { color: #57606a; margin-top: 0.2em; }
+  .stats { display: flex; gap: 1em; flex-wrap: wrap; margin: 1.5em 0; }
+  .stat { border: 1px solid #d0d7de; border-radius: 6px; padding: 0.6em 1em; min-width: 120px; }
+  .stat b { display: block; font-size: 1.6em; }
+  section { margin: 2em 0; overflow-x: auto; }
+  table { border-collapse: collapse; width: 100%; font-size: 0.9em; }
+  th, td { border-bottom: 1px solid #d0d7de; padding: 0.35em 0.6em; text-align: left; vertical-align: top; }
+  th { background: #f6f8fa; }
+  td.notes { white-space: pre-wrap; color: #57606a; }
+</style>
+</head>
+<body>
+<h1>todoplusplus progress report</h1>
+<p class="generated">Generated {{.Generated}}</p>
+
+<div class="stats">
+  <div class="stat"><b>{{.Total}}</b>logs</div>
+  <div class="stat"><b>{{.Solved}}</b>solved</div>
+  <div class="stat"><b>{{.Hours}}</b>hours</div>
+  <div class="stat"><b>{{.CurrentStreak}}</b>day streak</div>
+  <div class="stat"><b>{{.LongestStreak}}</b>longest streak</div>
+</div>
+
+<section><h2>Activity</h2>{{.Heatmap}}</section>
+{{if .Topics}}<section><h2>Topics</h2>{{.Topics}}</section>{{end}}
+{{if .Difficulty}}<section><h2>Difficulty over time</h2>{{.Difficulty}}</section>{{end}}
+{{if .Streaks}}<section><h2>Streak history</h2>{{.Streaks}}</section>{{end}}
+
+<section>
+<h2>Recent logs</h2>
+<table>
+<tr><th>Date</th><th>Platform</th><th>Question ID</th><th>Topic</th><th>Difficulty</th><th>Status</th><th>Time</th><th>Notes</th></tr>
+{{range .Recent}}<tr><td>{{.Date.Format "2006-01-02"}}</td><td>{{.Platform}}</td><td>{{.QuestionID}}</td><td>{{.Topic}}</td><td>{{.Difficulty}}</td><td>{{.EffectiveStatus}}</td><td>{{.TimeSpent}}m</td><td class="notes">{{.Notes}}</td></tr>
+{{else}}<tr><td colspan="8">No logs yet.</td></tr>
+{{end}}</table>
+</section>
+</body>
+</html>
+`))
@@ -0,0 +1,64 @@
+package export
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/Harschmann/Todo-/calendar"
+	"github.com/Harschmann/Todo-/model"
+)
+
+// ICS writes every log as an all-day VEVENT in a single iCalendar file, with
+// the same summary and description as the Google Calendar sync.
+type ICS struct{}
+
+func (ICS) Extension() string { return "ics" }
+
+var icsEscaper = strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\r\n", `\n`, "\n", `\n`)
+
+func (ICS) Export(w io.Writer, logs []model.Log) error {
+	bw := bufio.NewWriter(w)
+	stamp := time.Now().UTC().Format("20060102T150405Z")
+	writeICSLine(bw, "BEGIN:VCALENDAR")
+	writeICSLine(bw, "VERSION:2.0")
+	writeICSLine(bw, "PRODID:-//todoplusplus//Log Export//EN")
+	writeICSLine(bw, "CALSCALE:GREGORIAN")
+	for i := range logs {
+		l := &logs[i]
+		day := time.Date(l.Date.Year(), l.Date.Month(), l.Date.Day(), 0, 0, 0, 0, time.UTC)
+		writeICSLine(bw, "BEGIN:VEVENT")
+		// The log's timestamp is its database key, so re-importing an export
+		// updates its events rather than duplicating them. Redating a log
+		// changes its key, so its event then gets a new UID.
+		writeICSLine(bw, fmt.Sprintf("UID:%d@todoplusplus", l.Date.UnixNano()))
+		writeICSLine(bw, "DTSTAMP:"+stamp)
+		writeICSLine(bw, "DTSTART;VALUE=DATE:"+day.Format("20060102"))
+		writeICSLine(bw, "DTEND;VALUE=DATE:"+day.AddDate(0, 0, 1).Format("20060102"))
+		writeICSLine(bw, "SUMMARY:"+icsEscaper.Replace(calendar.EventSummary(l)))
+		writeICSLine(bw, "DESCRIPTION:"+icsEscaper.Replace(calendar.EventDescription(l)))
+		writeICSLine(bw, "END:VEVENT")
+	}
+	writeICSLine(bw, "END:VCALENDAR")
+	return bw.Flush()
+}
+
+// writeICSLine writes a content line, folded at 75 octets as RFC 5545 requires
+// without splitting multi-byte characters.
+func writeICSLine(w *bufio.Writer, line string) {
+	limit := 75
+	for len(line) > limit {
+		cut := limit
+		for cut > 0 && !isRuneStart(line[cut]) {
+			cut--
+		}
+		w.WriteString(line[:cut] + "\r\n ")
+		line = line[cut:]
+		limit = 74 // Continuation lines start with a space
+	}
+	w.WriteString(line + "\r\n")
+}
+
+func isRuneStart(b byte) bool { return b&0xC0 != 0x80 }
@@ -0,0 +1,74 @@
+package export
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
+
+func TestICS(t *testing.T) {
+	var buf bytes.Buffer
+	if err := (ICS{}).Export(&buf, testLogs); err != nil {
+		t.Fatal(err)
+	}
+	out := buf.String()
+	for _, line := range strings.Split(strings.TrimSuffix(out, "\r\n"), "\r\n") {
+		if len(line) > 75 {
+			t.Errorf("line longer than 75 octets: %q", line)
+		}
+	}
+	// Unfold continuation lines before looking at the content.
+	unfolded := strings.ReplaceAll(out, "\r\n ", "")
+	for _, want := range []string{
+		"BEGIN:VCALENDAR\r\n",
+		"END:VCALENDAR\r\n",
+		fmt.Sprintf("UID:%d@todoplusplus\r\n", testLogs[0].Date.UnixNano()),
+		"DTSTART;VALUE=DATE:20260311\r\n",
+		"DTEND;VALUE=DATE:20260312\r\n",
+		"SUMMARY:CP: 1337A (Codeforces)\r\n",
+		`Notes:\ntried | dp\,\nwrong idea` + "\r\n",
+	} {
+		if !strings.Contains(unfolded, want) {
+			t.Errorf("export lacks %q:\n%s", want, unfolded)
+		}
+	}
+	if n := strings.Count(unfolded, "BEGIN:VEVENT"); n != len(testLogs) {
+		t.Errorf("got %d events, want %d", n, len(testLogs))
+	}
+}
+
+func TestWriteICSLineFolding(t *testing.T) {
+	line := "DESCRIPTION:" + strings.Repeat("é", 100)
+	var buf bytes.Buffer
+	w := bufio.NewWriter(&buf)
+	writeICSLine(w, line)
+	w.Flush()
+
+	parts := strings.Split(strings.TrimSuffix(buf.String(), "\r\n"), "\r\n")
+	if len(parts) < 3 {
+		t.Fatalf("got %d lines, want the line folded", len(parts))
+	}
+	var joined strings.Builder
+	for i, part := range parts {
+		if len(part) > 75 {
+			t.Errorf("line %d is %d octets", i, len(part))
+		}
+		if i > 0 {
+			if !strings.HasPrefix(part, " ") {
+				t.Errorf("continuation line %d does not start with a space", i)
+			}
+			part = part[1:]
+		}
+		// Folding never splits a character.
+		if !utf8.ValidString(part) {
+			t.Errorf("line %d splits a character: %q", i, part)
+		}
+		joined.WriteString(part)
+	}
+	if joined.String() != line {
+		t.Errorf("unfolded line = %q, want %q", joined.String(), line)
+	}
+}
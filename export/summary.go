@@ -0,0 +1,85 @@
+package export
+
+import (
+	"sort"
+	"time"
+
+	"github.com/Harschmann/Todo-/config"
+	"github.com/Harschmann/Todo-/model"
+	"github.com/Harschmann/Todo-/utils"
+)
+
+// SummaryRow aggregates the logs sharing one key, e.g. a topic or a month.
+type SummaryRow struct {
+	Key     string
+	Logs    int
+	Solved  int // Logs whose status counts as solved
+	Minutes int
+}
+
+// AvgMinutes is the average time spent per log.
+func (r SummaryRow) AvgMinutes() float64 {
+	if r.Logs == 0 {
+		return 0
+	}
+	return float64(r.Minutes) / float64(r.Logs)
+}
+
+// Summarize groups logs by key, sorted by key.
+func Summarize(logs []model.Log, key func(model.Log) string) []SummaryRow {
+	byKey := make(map[string]*SummaryRow)
+	for _, l := range logs {
+		k := key(l)
+		row, ok := byKey[k]
+		if !ok {
+			row = &SummaryRow{Key: k}
+			byKey[k] = row
+		}
+		row.Logs++
+		row.Minutes += l.TimeSpent
+		if config.Get().Counts(l.EffectiveStatus()) {
+			row.Solved++
+		}
+	}
+	rows := make([]SummaryRow, 0, len(byKey))
+	for _, row := range byKey {
+		rows = append(rows, *row)
+	}
+	sort.Slice(rows, func(i, j int) bool { return rows[i].Key < rows[j].Key })
+	return rows
+}
+
+func ByTopic(l model.Log) string      { return l.Topic }
+func ByDifficulty(l model.Log) string { return l.Difficulty }
+func ByMonth(l model.Log) string      { return l.Date.Format("2006-01") }
+
+// WeekCount is the number of logs in the week starting on Start (a Monday).
+type WeekCount struct {
+	Start time.Time
+	Count int
+}
+
+// WeeklyCounts counts logs per week from the first to the last logged week,
+// including weeks without any logs.
+func WeeklyCounts(logs []model.Log) []WeekCount {
+	if len(logs) == 0 {
+		return nil
+	}
+	counts := make(map[time.Time]int)
+	first, last := utils.StartOfWeek(logs[0].Date), utils.StartOfWeek(logs[0].Date)
+	for _, l := range logs {
+		w := utils.StartOfWeek(l.Date)
+		counts[w]++
+		if w.Before(first) {
+			first = w
+		}
+		if w.After(last) {
+			last = w
+		}
+	}
+	var weeks []WeekCount
+	for w := first; !w.After(last); w = w.AddDate(0, 0, 7) {
+		weeks = append(weeks, WeekCount{Start: w, Count: counts[w]})
+	}
+	return weeks
+}
@@ -0,0 +1,57 @@
+package export
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Harschmann/Todo-/model"
+)
+
+func TestSummarize(t *testing.T) {
+	logs := []model.Log{
+		{Topic: "DP", Status: model.StatusAccepted, TimeSpent: 40},
+		{Topic: "DP", Status: model.StatusAttempted, TimeSpent: 25},
+		{Topic: "Greedy", TimeSpent: 10},
+	}
+	rows := Summarize(logs, ByTopic)
+	want := []SummaryRow{
+		{Key: "DP", Logs: 2, Solved: 1, Minutes: 65},
+		{Key: "Greedy", Logs: 1, Solved: 1, Minutes: 10},
+	}
+	if len(rows) != len(want) {
+		t.Fatalf("rows = %+v, want %+v", rows, want)
+	}
+	for i := range want {
+		if rows[i] != want[i] {
+			t.Errorf("row %d = %+v, want %+v", i, rows[i], want[i])
+		}
+	}
+	if avg := rows[0].AvgMinutes(); avg != 32.5 {
+		t.Errorf("AvgMinutes = %v, want 32.5", avg)
+	}
+	if avg := (SummaryRow{}).AvgMinutes(); avg != 0 {
+		t.Errorf("AvgMinutes of no logs = %v, want 0", avg)
+	}
+}
+
+func TestWeeklyCounts(t *testing.T) {
+	day := func(d, hour int) time.Time { return time.Date(2026, 3, d, hour, 0, 0, 0, time.Local) }
+	logs := []model.Log{
+		{Date: day(15, 23)}, // Sunday, in the week of Monday the 9th
+		{Date: day(9, 0)},
+		{Date: day(30, 12)}, // Two weeks later, with an empty week between
+	}
+	weeks := WeeklyCounts(logs)
+	want := []WeekCount{{day(9, 0), 2}, {day(16, 0), 0}, {day(23, 0), 0}, {day(30, 0), 1}}
+	if len(weeks) != len(want) {
+		t.Fatalf("weeks = %+v, want %+v", weeks, want)
+	}
+	for i := range want {
+		if !weeks[i].Start.Equal(want[i].Start) || weeks[i].Count != want[i].Count {
+			t.Errorf("week %d = %+v, want %+v", i, weeks[i], want[i])
+		}
+	}
+	if WeeklyCounts(nil) != nil {
+		t.Error("WeeklyCounts(nil) should be empty")
+	}
+}
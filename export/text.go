@@ -0,0 +1,67 @@
+package export
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/Harschmann/Todo-/model"
+)
+
+// CSV writes one row per log under a header row.
+type CSV struct{}
+
+func (CSV) Extension() string { return "csv" }
+
+func (CSV) Export(w io.Writer, logs []model.Log) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write(Headers); err != nil {
+		return err
+	}
+	for _, l := range logs {
+		if err := cw.Write(record(l)); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// JSON writes the logs as an indented array, in the same shape as backups.
+type JSON struct{}
+
+func (JSON) Extension() string { return "json" }
+
+func (JSON) Export(w io.Writer, logs []model.Log) error {
+	if logs == nil {
+		logs = []model.Log{}
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(logs)
+}
+
+// Markdown writes a GitHub-flavored Markdown table.
+type Markdown struct{}
+
+func (Markdown) Extension() string { return "md" }
+
+var markdownEscaper = strings.NewReplacer("|", `\|`, "\r\n", "<br>", "\n", "<br>")
+
+func (Markdown) Export(w io.Writer, logs []model.Log) error {
+	if _, err := fmt.Fprintf(w, "| %s |\n|%s\n", strings.Join(Headers, " | "), strings.Repeat(" --- |", len(Headers))); err != nil {
+		return err
+	}
+	for _, l := range logs {
+		values := record(l)
+		for i, v := range values {
+			values[i] = markdownEscaper.Replace(v)
+		}
+		if _, err := fmt.Fprintf(w, "| %s |\n", strings.Join(values, " | ")); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,72 @@
+package export
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/Harschmann/Todo-/model"
+)
+
+func TestCSV(t *testing.T) {
+	var buf bytes.Buffer
+	if err := (CSV{}).Export(&buf, testLogs); err != nil {
+		t.Fatal(err)
+	}
+	rows, err := csv.NewReader(&buf).ReadAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rows) != 3 || !slices.Equal(rows[0], Headers) {
+		t.Fatalf("rows = %q, want the headers and 2 logs", rows)
+	}
+	// Commas and newlines in notes survive the round trip.
+	if !slices.Equal(rows[1], record(testLogs[0])) {
+		t.Errorf("row = %q, want %q", rows[1], record(testLogs[0]))
+	}
+}
+
+func TestJSON(t *testing.T) {
+	var buf bytes.Buffer
+	if err := (JSON{}).Export(&buf, testLogs); err != nil {
+		t.Fatal(err)
+	}
+	var logs []model.Log
+	if err := json.Unmarshal(buf.Bytes(), &logs); err != nil {
+		t.Fatal(err)
+	}
+	if len(logs) != 2 || logs[0].QuestionID != "1337A" || !logs[0].Date.Equal(testLogs[0].Date) || logs[0].Notes != testLogs[0].Notes {
+		t.Errorf("logs = %+v", logs)
+	}
+
+	// No logs is an empty array rather than null.
+	buf.Reset()
+	if err := (JSON{}).Export(&buf, nil); err != nil {
+		t.Fatal(err)
+	}
+	if got := strings.TrimSpace(buf.String()); got != "[]" {
+		t.Errorf("empty export = %q, want []", got)
+	}
+}
+
+func TestMarkdown(t *testing.T) {
+	var buf bytes.Buffer
+	if err := (Markdown{}).Export(&buf, testLogs); err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(strings.TrimRight(buf.String(), "\n"), "\n")
+	if len(lines) != 4 {
+		t.Fatalf("got %d lines, want a header, a separator and 2 rows:\n%s", len(lines), buf.String())
+	}
+	if lines[1] != "|"+strings.Repeat(" --- |", len(Headers)) {
+		t.Errorf("separator = %q", lines[1])
+	}
+	// Pipes and newlines in notes must not break the table.
+	want := `| 2026-03-11 20:15 | Codeforces | 1337A | DP | 1600 | Attempted | 45 | tried \| dp,<br>wrong idea |`
+	if lines[2] != want {
+		t.Errorf("row = %q, want %q", lines[2], want)
+	}
+}
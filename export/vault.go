@@ -0,0 +1,306 @@
+package export
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/Harschmann/Todo-/db"
+	"github.com/Harschmann/Todo-/model"
+)
+
+// vaultManifest records the hash of every file the vault export last wrote,
+// so files edited by hand since then can be told apart and left alone.
+const vaultManifest = ".todoplusplus-vault.json"
+
+// VaultResult reports what a vault export did.
+type VaultResult struct {
+	Written   []string
+	Unchanged int
+	Kept      []string // Files edited by hand that were not overwritten or removed
+	Removed   []string // Notes of logs or problems that are gone or were renamed
+}
+
+type vaultNote struct {
+	path    string // Relative to the vault root, with forward slashes
+	link    string // Wiki link target: the file name without extension
+	topic   string
+	content string
+}
+
+var fileNameEscaper = strings.NewReplacer("/", "-", `\`, "-", ":", "-", "*", "-", "?", "", `"`, "", "<", "", ">", "", "|", "-")
+
+// WriteVault writes one Markdown note per log (or per problem if perProblem is
+// set) with YAML front matter, plus an index page per topic, into dir. Running
+// it again only rewrites files that were not edited since the last run, and
+// removes the unedited notes it wrote before that it no longer writes.
+func WriteVault(dir string, logs []model.Log, perProblem bool) (VaultResult, error) {
+	var result VaultResult
+	problems, err := db.GetAllProblems()
+	if err != nil {
+		return result, err
+	}
+	catalog := make(map[string]model.Problem, len(problems))
+	for _, p := range problems {
+		catalog[p.Key] = p
+	}
+
+	var notes []vaultNote
+	if perProblem {
+		notes = problemNotes(logs, catalog)
+	} else {
+		notes = logNotes(logs, catalog)
+	}
+	notes = append(notes, indexNotes(notes)...)
+
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return result, err
+	}
+	manifest := make(map[string]string)
+	manifestPath := filepath.Join(dir, vaultManifest)
+	if data, err := os.ReadFile(manifestPath); err == nil {
+		if err := json.Unmarshal(data, &manifest); err != nil {
+			return result, fmt.Errorf("could not parse %s: %w", vaultManifest, err)
+		}
+	} else if !errors.Is(err, fs.ErrNotExist) {
+		return result, err
+	}
+
+	for _, note := range notes {
+		full := filepath.Join(dir, filepath.FromSlash(note.path))
+		newHash := hashContent(note.content)
+		existing, err := os.ReadFile(full)
+		switch {
+		case errors.Is(err, fs.ErrNotExist):
+		case err != nil:
+			return result, err
+		case hashContent(string(existing)) == newHash:
+			manifest[note.path] = newHash
+			result.Unchanged++
+			continue
+		case hashContent(string(existing)) != manifest[note.path]:
+			result.Kept = append(result.Kept, note.path)
+			continue
+		}
+		if err := os.MkdirAll(filepath.Dir(full), 0755); err != nil {
+			return result, err
+		}
+		if err := os.WriteFile(full, []byte(note.content), 0644); err != nil {
+			return result, err
+		}
+		manifest[note.path] = newHash
+		result.Written = append(result.Written, note.path)
+	}
+	if err := removeStaleNotes(dir, notes, manifest, &result); err != nil {
+		return result, err
+	}
+
+	data, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return result, err
+	}
+	return result, os.WriteFile(manifestPath, data, 0644)
+}
+
+// removeStaleNotes deletes the files in the manifest that this export did not
+// write, such as notes of deleted logs, if they were not edited since. Only
+// folders the export wrote to are touched, so per-log and per-problem notes
+// can share a vault. Stale files edited by hand are kept and forgotten.
+func removeStaleNotes(dir string, notes []vaultNote, manifest map[string]string, result *VaultResult) error {
+	written := make(map[string]bool, len(notes))
+	folders := make(map[string]bool)
+	for _, note := range notes {
+		written[note.path] = true
+		if folder, _, ok := strings.Cut(note.path, "/"); ok {
+			folders[folder] = true
+		}
+	}
+	for path, hash := range manifest {
+		folder, _, ok := strings.Cut(path, "/")
+		if written[path] || !ok || !folders[folder] {
+			continue
+		}
+		full := filepath.Join(dir, filepath.FromSlash(path))
+		existing, err := os.ReadFile(full)
+		switch {
+		case errors.Is(err, fs.ErrNotExist):
+		case err != nil:
+			return err
+		case hashContent(string(existing)) != hash:
+			result.Kept = append(result.Kept, path)
+		default:
+			if err := os.Remove(full); err != nil {
+				return err
+			}
+			result.Removed = append(result.Removed, path)
+		}
+		delete(manifest, path)
+	}
+	sort.Strings(result.Removed)
+	return nil
+}
+
+// noteName joins parts into a file name. Names depend only on the note's own
+// log or problem, so they stay put when other logs change. If escaping
+// altered the name, a hash of key keeps it apart from others escaping alike.
+func noteName(key string, parts ...string) string {
+	name := strings.Join(parts, " - ")
+	escaped := fileNameEscaper.Replace(name)
+	if escaped != name {
+		escaped += " " + hashContent(key)[:8]
+	}
+	return escaped
+}
+
+func hashContent(content string) string {
+	sum := sha256.Sum256([]byte(content))
+	return hex.EncodeToString(sum[:])
+}
+
+// frontMatter renders YAML front matter. Values are double-quoted so any
+// characters are safe.
+func frontMatter(fields [][2]string, tags []string) string {
+	var b strings.Builder
+	b.WriteString("---\n")
+	for _, f := range fields {
+		if f[1] != "" {
+			fmt.Fprintf(&b, "%s: %s\n", f[0], strconv.Quote(f[1]))
+		}
+	}
+	if len(tags) > 0 {
+		b.WriteString("tags:\n")
+		for _, t := range tags {
+			fmt.Fprintf(&b, "  - %s\n", strconv.Quote(t))
+		}
+	}
+	b.WriteString("---\n\n")
+	return b.String()
+}
+
+func problemURL(l model.Log, catalog map[string]model.Problem) string {
+	if p, ok := catalog[l.ProblemKey()]; ok && p.URL != "" {
+		return p.URL
+	}
+	return db.ProblemURL(l)
+}
+
+func logNotes(logs []model.Log, catalog map[string]model.Problem) []vaultNote {
+	notes := make([]vaultNote, 0, len(logs))
+	for _, l := range logs {
+		// Logs are stored by their exact time, so down to the nanosecond it
+		// tells them apart even when imported ones share a second.
+		name := noteName(strconv.FormatInt(l.Date.UnixNano(), 10), l.Platform, l.QuestionID, l.Date.Format("2006-01-02 150405.999999999"))
+		problem := catalog[l.ProblemKey()]
+		var b strings.Builder
+		b.WriteString(frontMatter([][2]string{
+			{"platform", l.Platform},
+			{"question_id", l.QuestionID},
+			{"title", problem.Title},
+			{"topic", l.Topic},
+			{"difficulty", l.Difficulty},
+			{"status", l.EffectiveStatus()},
+			{"date", l.Date.Format("2006-01-02T15:04:05Z07:00")},
+			{"time_spent", strconv.Itoa(l.TimeSpent)},
+			{"url", problemURL(l, catalog)},
+		}, problem.Tags))
+		fmt.Fprintf(&b, "# %s (%s)\n\n", l.QuestionID, l.Platform)
+		b.WriteString(noteBody(l.Notes))
+		notes = append(notes, vaultNote{path: "Logs/" + name + ".md", link: name, topic: l.Topic, content: b.String()})
+	}
+	return notes
+}
+
+func problemNotes(logs []model.Log, catalog map[string]model.Problem) []vaultNote {
+	var keys []string
+	attempts := make(map[string][]model.Log)
+	for _, l := range logs {
+		key := l.ProblemKey()
+		if _, ok := attempts[key]; !ok {
+			keys = append(keys, key)
+		}
+		attempts[key] = append(attempts[key], l)
+	}
+
+	notes := make([]vaultNote, 0, len(keys))
+	for _, key := range keys {
+		all := attempts[key]
+		first, last := all[0], all[len(all)-1]
+		problem := catalog[key]
+		name := noteName(key, first.Platform, first.QuestionID)
+		if problem.Platform != "" && problem.ProblemID != "" {
+			name = noteName(key, problem.Platform, problem.ProblemID)
+		}
+		total := 0
+		for _, l := range all {
+			total += l.TimeSpent
+		}
+		var b strings.Builder
+		b.WriteString(frontMatter([][2]string{
+			{"platform", last.Platform},
+			{"question_id", last.QuestionID},
+			{"title", problem.Title},
+			{"topic", last.Topic},
+			{"difficulty", last.Difficulty},
+			{"status", last.EffectiveStatus()},
+			{"date", first.Date.Format("2006-01-02T15:04:05Z07:00")},
+			{"last_attempt", last.Date.Format("2006-01-02T15:04:05Z07:00")},
+			{"attempts", strconv.Itoa(len(all))},
+			{"time_spent", strconv.Itoa(total)},
+			{"url", problemURL(last, catalog)},
+		}, problem.Tags))
+		fmt.Fprintf(&b, "# %s (%s)\n", last.QuestionID, last.Platform)
+		for _, l := range all {
+			fmt.Fprintf(&b, "\n## %s — %s, %d mins\n\n", l.Date.Format("2006-01-02 15:04"), l.EffectiveStatus(), l.TimeSpent)
+			b.WriteString(noteBody(l.Notes))
+		}
+		notes = append(notes, vaultNote{path: "Problems/" + name + ".md", link: name, topic: last.Topic, content: b.String()})
+	}
+	return notes
+}
+
+func noteBody(notes string) string {
+	if strings.TrimSpace(notes) == "" {
+		return "_No notes._\n"
+	}
+	return strings.TrimRight(notes, "\n") + "\n"
+}
+
+// indexNotes builds one page per topic linking its notes, and a root index.
+func indexNotes(notes []vaultNote) []vaultNote {
+	byTopic := make(map[string][]string)
+	for _, n := range notes {
+		topic := n.topic
+		if topic == "" {
+			topic = "No Topic"
+		}
+		byTopic[topic] = append(byTopic[topic], n.link)
+	}
+	topics := make([]string, 0, len(byTopic))
+	for t := range byTopic {
+		topics = append(topics, t)
+	}
+	sort.Strings(topics)
+
+	var index strings.Builder
+	index.WriteString("# Topics\n\n")
+	var pages []vaultNote
+	for _, t := range topics {
+		name := fileNameEscaper.Replace(t)
+		fmt.Fprintf(&index, "- [[%s]] (%d)\n", name, len(byTopic[t]))
+		var b strings.Builder
+		fmt.Fprintf(&b, "# %s\n\n", t)
+		for _, link := range byTopic[t] {
+			fmt.Fprintf(&b, "- [[%s]]\n", link)
+		}
+		pages = append(pages, vaultNote{path: "Topics/" + name + ".md", link: name, content: b.String()})
+	}
+	return append(pages, vaultNote{path: "Index.md", link: "Index", content: index.String()})
+}
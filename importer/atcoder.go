@@ -0,0 +1,71 @@
+package importer
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/Harschmann/Todo-/model"
+	"github.com/Harschmann/Todo-/platform"
+)
+
+// atcoderSubmission mirrors one element of the JSON array returned by
+// https://kenkoooo.com/atcoder/atcoder-api/v3/user/submissions?user=<user>&from_second=0.
+type atcoderSubmission struct {
+	ID          int64   `json:"id"`
+	EpochSecond int64   `json:"epoch_second"`
+	ProblemID   string  `json:"problem_id"`
+	ContestID   string  `json:"contest_id"`
+	Language    string  `json:"language"`
+	Point       float64 `json:"point"`
+	Result      string  `json:"result"`
+}
+
+// ParseAtCoder turns the accepted submissions of a saved AtCoder Problems
+// submission list into log entries, using the task's points as difficulty.
+func ParseAtCoder(r io.Reader) ([]Entry, error) {
+	var subs []atcoderSubmission
+	if err := json.NewDecoder(r).Decode(&subs); err != nil {
+		return nil, fmt.Errorf("could not parse AtCoder submissions: %w", err)
+	}
+
+	var entries []Entry
+	for _, sub := range subs {
+		if sub.Result != "AC" {
+			continue
+		}
+		ref, err := platform.Resolve("AtCoder", sub.ProblemID)
+		if err != nil {
+			entries = append(entries, invalidEntry("AtCoder", sub.ProblemID, err))
+			continue
+		}
+		// Task IDs do not always start with their contest ID, so link the contest the submission was made in.
+		url := ref.URL
+		if sub.ContestID != "" {
+			url = fmt.Sprintf("https://atcoder.jp/contests/%s/tasks/%s", sub.ContestID, ref.ID)
+		}
+		difficulty := "Unrated"
+		if sub.Point > 0 {
+			difficulty = fmt.Sprintf("%.0f pts", sub.Point)
+		}
+		entries = append(entries, Entry{
+			Log: model.Log{
+				QuestionID: ref.ID,
+				Platform:   "AtCoder",
+				Topic:      topicForTags(nil),
+				Difficulty: difficulty,
+				Status:     model.StatusAccepted,
+				Notes:      fmt.Sprintf("Imported from AtCoder submission %d (%s).", sub.ID, sub.Language),
+				Date:       time.Unix(sub.EpochSecond, 0),
+			},
+			Problem: model.Problem{
+				Platform:  "AtCoder",
+				ProblemID: ref.ID,
+				Title:     ref.ID,
+				URL:       url,
+			},
+		})
+	}
+	return entries, nil
+}
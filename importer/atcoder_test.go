@@ -0,0 +1,38 @@
+package importer
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseAtCoder(t *testing.T) {
+	in := `[
+		{"id": 21, "epoch_second": 1700000000, "problem_id": "abc300_a", "contest_id": "abc300", "language": "C++", "point": 100, "result": "AC"},
+		{"id": 22, "epoch_second": 1700000100, "problem_id": "abc300_b", "contest_id": "abc300", "language": "C++", "point": 200, "result": "WA"},
+		{"id": 23, "epoch_second": 1700000200, "problem_id": "arc104_a", "contest_id": "abc183", "language": "Go", "result": "AC"},
+		{"id": 24, "epoch_second": 1700000300, "problem_id": "not a task!", "result": "AC"}
+	]`
+	entries, err := ParseAtCoder(strings.NewReader(in))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 3 {
+		t.Fatalf("got %d entries, want 3: %+v", len(entries), entries)
+	}
+
+	first := entries[0]
+	if first.Err != nil || first.Log.QuestionID != "abc300_a" || first.Log.Difficulty != "100 pts" || !first.Log.Date.Equal(time.Unix(1700000000, 0)) {
+		t.Errorf("first entry = %+v", first)
+	}
+
+	// The URL points at the contest the task was solved in.
+	shared := entries[1]
+	if shared.Err != nil || shared.Log.Difficulty != "Unrated" || shared.Problem.URL != "https://atcoder.jp/contests/abc183/tasks/arc104_a" {
+		t.Errorf("shared task entry = %+v", shared)
+	}
+
+	if entries[2].Err == nil {
+		t.Errorf("an unresolvable task ID should be invalid, got %+v", entries[2])
+	}
+}
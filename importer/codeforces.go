@@ -0,0 +1,83 @@
+package importer
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+
+	"github.com/Harschmann/Todo-/model"
+	"github.com/Harschmann/Todo-/platform"
+)
+
+// codeforcesResponse mirrors the JSON returned by
+// https://codeforces.com/api/user.status?handle=<handle>.
+type codeforcesResponse struct {
+	Status  string                 `json:"status"`
+	Comment string                 `json:"comment"`
+	Result  []codeforcesSubmission `json:"result"`
+}
+
+type codeforcesSubmission struct {
+	ID                  int64  `json:"id"`
+	CreationTimeSeconds int64  `json:"creationTimeSeconds"`
+	Verdict             string `json:"verdict"`
+	Problem             struct {
+		ContestID int      `json:"contestId"`
+		Index     string   `json:"index"`
+		Name      string   `json:"name"`
+		Rating    int      `json:"rating"`
+		Tags      []string `json:"tags"`
+	} `json:"problem"`
+}
+
+// ParseCodeforces turns the accepted submissions of a saved user.status
+// response into log entries. Problems outside of contests are skipped since
+// they have no stable ID.
+func ParseCodeforces(r io.Reader) ([]Entry, error) {
+	var resp codeforcesResponse
+	if err := json.NewDecoder(r).Decode(&resp); err != nil {
+		return nil, fmt.Errorf("could not parse Codeforces response: %w", err)
+	}
+	if resp.Status != "" && resp.Status != "OK" {
+		return nil, fmt.Errorf("codeforces response has status %s: %s", resp.Status, resp.Comment)
+	}
+
+	var entries []Entry
+	for _, sub := range resp.Result {
+		if sub.Verdict != "OK" || sub.Problem.ContestID == 0 {
+			continue
+		}
+		ref, err := platform.Resolve("Codeforces", fmt.Sprintf("%d%s", sub.Problem.ContestID, sub.Problem.Index))
+		if err != nil {
+			entries = append(entries, invalidEntry("Codeforces", fmt.Sprintf("%d%s", sub.Problem.ContestID, sub.Problem.Index), err))
+			continue
+		}
+		difficulty := "Unrated"
+		if sub.Problem.Rating > 0 {
+			difficulty = strconv.Itoa(sub.Problem.Rating)
+		}
+		logEntry := model.Log{
+			QuestionID: ref.ID,
+			Platform:   "Codeforces",
+			Topic:      topicForTags(sub.Problem.Tags),
+			Difficulty: difficulty,
+			Status:     model.StatusAccepted,
+			Notes:      fmt.Sprintf("Imported from Codeforces submission %d.", sub.ID),
+			Date:       time.Unix(sub.CreationTimeSeconds, 0),
+		}
+		entries = append(entries, Entry{
+			Log: logEntry,
+			Problem: model.Problem{
+				Platform:  "Codeforces",
+				ProblemID: ref.ID,
+				Title:     sub.Problem.Name,
+				URL:       ref.URL,
+				Rating:    sub.Problem.Rating,
+				Tags:      sub.Problem.Tags,
+			},
+		})
+	}
+	return entries, nil
+}
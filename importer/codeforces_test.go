@@ -0,0 +1,91 @@
+package importer
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Harschmann/Todo-/model"
+)
+
+const codeforcesStatus = `{
+  "status": "OK",
+  "result": [
+    {"id": 1, "creationTimeSeconds": 1700000000, "verdict": "OK",
+     "problem": {"contestId": 1337, "index": "a", "name": "Ichihime and Triangle", "rating": 800, "tags": ["greedy", "dp"]}},
+    {"id": 2, "creationTimeSeconds": 1700000100, "verdict": "WRONG_ANSWER",
+     "problem": {"contestId": 1337, "index": "B", "name": "Kana and Dragon Quest game"}},
+    {"id": 3, "creationTimeSeconds": 1700000200, "verdict": "OK",
+     "problem": {"index": "A", "name": "Gym problem"}},
+    {"id": 4, "creationTimeSeconds": 1700000300, "verdict": "OK",
+     "problem": {"contestId": 1338, "index": "", "name": "No index"}},
+    {"id": 5, "creationTimeSeconds": 1700000400, "verdict": "OK",
+     "problem": {"contestId": 1339, "index": "C1", "name": "Unrated", "tags": ["interactive"]}}
+  ]
+}`
+
+func TestParseCodeforces(t *testing.T) {
+	entries, err := ParseCodeforces(strings.NewReader(codeforcesStatus))
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Wrong answers and problems outside contests are skipped.
+	if len(entries) != 3 {
+		t.Fatalf("got %d entries, want 3: %+v", len(entries), entries)
+	}
+
+	first := entries[0]
+	if first.Err != nil {
+		t.Fatalf("first entry: %v", first.Err)
+	}
+	want := model.Log{
+		QuestionID: "1337A",
+		Platform:   "Codeforces",
+		Topic:      "DP",
+		Difficulty: "800",
+		Status:     model.StatusAccepted,
+		Notes:      "Imported from Codeforces submission 1.",
+		Date:       time.Unix(1700000000, 0),
+	}
+	if first.Log != want {
+		t.Errorf("log = %+v, want %+v", first.Log, want)
+	}
+	if p := first.Problem; p.Title != "Ichihime and Triangle" || p.Rating != 800 || p.URL == "" || p.ProblemID != "1337A" {
+		t.Errorf("problem = %+v", p)
+	}
+
+	if entries[1].Err == nil || entries[1].Log.QuestionID != "1338" {
+		t.Errorf("a problem without an index should be invalid, got %+v", entries[1])
+	}
+
+	last := entries[2]
+	if last.Err != nil || last.Log.QuestionID != "1339C1" || last.Log.Difficulty != "Unrated" || last.Log.Topic != "Ad-Hoc" {
+		t.Errorf("unrated entry = %+v", last)
+	}
+}
+
+func TestParseCodeforcesErrors(t *testing.T) {
+	for _, in := range []string{`{"status": "FAILED", "comment": "handle not found"}`, `not json`} {
+		if _, err := ParseCodeforces(strings.NewReader(in)); err == nil {
+			t.Errorf("ParseCodeforces(%q) succeeded, want an error", in)
+		}
+	}
+}
+
+func TestTopicForTags(t *testing.T) {
+	tests := []struct {
+		tags []string
+		want string
+	}{
+		{[]string{"greedy", "dp"}, "DP"},
+		{[]string{"math", "greedy"}, "Math"},
+		{[]string{"dfs and similar", "trees"}, "Graphs"},
+		{[]string{"unknown tag"}, "Ad-Hoc"},
+		{nil, "Ad-Hoc"},
+	}
+	for _, tt := range tests {
+		if got := topicForTags(tt.tags); got != tt.want {
+			t.Errorf("topicForTags(%q) = %q, want %q", tt.tags, got, tt.want)
+		}
+	}
+}
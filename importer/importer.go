@@ -0,0 +1,104 @@
+package importer
+
+import (
+	"io"
+	"sort"
+
+	"github.com/Harschmann/Todo-/db"
+	"github.com/Harschmann/Todo-/model"
+)
+
+// Entry is one imported log together with what the source knows about its
+// problem. Err is set if the entry cannot be imported, e.g. because its
+// question ID does not resolve.
+type Entry struct {
+	Log     model.Log
+	Problem model.Problem
+	Err     error
+}
+
+// Parsers maps each supported source to the parser for its exported file.
+var Parsers = map[string]func(r io.Reader) ([]Entry, error){
+	"codeforces": ParseCodeforces,
+	"leetcode":   ParseLeetCode,
+	"atcoder":    ParseAtCoder,
+}
+
+// invalidEntry records a submission whose question ID did not resolve.
+func invalidEntry(platformName, questionID string, err error) Entry {
+	return Entry{Log: model.Log{Platform: platformName, QuestionID: questionID}, Err: err}
+}
+
+// Result reports what an import added and what it skipped.
+type Result struct {
+	Added   []Entry
+	Skipped int     // Entries that were already logged or repeated in the input
+	Invalid []Entry // Entries that could not be imported, with their Err
+}
+
+// Options controls how Import deduplicates and whether it saves anything.
+type Options struct {
+	// DryRun lists what would be added without saving.
+	DryRun bool
+	// KeepAttempts deduplicates per problem, status and minute instead of
+	// per problem and status, so spreadsheets with several attempts at a
+	// problem keep all of them, even on one day, while importing the same
+	// file twice still adds nothing.
+	KeepAttempts bool
+}
+
+// dedupeKey includes the status, so an imported AC is still added as a new
+// attempt at a problem that was only attempted so far.
+func (o Options) dedupeKey(logEntry model.Log) string {
+	key := logEntry.ProblemKey() + "|" + logEntry.EffectiveStatus()
+	if o.KeepAttempts {
+		return key + "@" + logEntry.Date.Format("2006-01-02 15:04")
+	}
+	return key
+}
+
+// Import saves entries that are not logged yet, so importing the same history
+// twice, or a solve already logged by hand, adds nothing. Entries are
+// deduplicated per problem and status unless opts.KeepAttempts is set, which
+// keeps one per minute too.
+func Import(entries []Entry, opts Options) (Result, error) {
+	var result Result
+	existing, err := db.GetAllLogs()
+	if err != nil {
+		return result, err
+	}
+	seen := make(map[string]bool, len(existing))
+	for _, logEntry := range existing {
+		seen[opts.dedupeKey(logEntry)] = true
+	}
+
+	sorted := append([]Entry(nil), entries...)
+	sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].Log.Date.Before(sorted[j].Log.Date) })
+	for _, e := range sorted {
+		if e.Err != nil {
+			result.Invalid = append(result.Invalid, e)
+			continue
+		}
+		key := opts.dedupeKey(e.Log)
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		result.Added = append(result.Added, e)
+	}
+	result.Skipped = len(entries) - len(result.Added) - len(result.Invalid)
+	if opts.DryRun {
+		return result, nil
+	}
+
+	logs := make([]model.Log, len(result.Added))
+	problems := make([]model.Problem, len(result.Added))
+	for i, e := range result.Added {
+		logs[i] = e.Log
+		problems[i] = e.Problem
+	}
+	if err := db.ImportLogs(logs, problems); err != nil {
+		return Result{}, err
+	}
+	return result, nil
+}
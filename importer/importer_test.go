@@ -0,0 +1,31 @@
+package importer
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Harschmann/Todo-/model"
+)
+
+func TestDedupeKey(t *testing.T) {
+	at := time.Date(2026, 3, 11, 20, 0, 0, 0, time.Local)
+	ac := model.Log{Platform: "Codeforces", QuestionID: "1337A", Date: at}
+	tests := []struct {
+		name  string
+		a, b  model.Log
+		opts  Options
+		equal bool
+	}{
+		{"same problem", ac, model.Log{Platform: "codeforces", QuestionID: "1337a", Date: at.AddDate(0, 0, -3)}, Options{}, true},
+		{"empty status is AC", ac, model.Log{Platform: "Codeforces", QuestionID: "1337A", Status: model.StatusAccepted}, Options{}, true},
+		{"an AC after an attempt is new", ac, model.Log{Platform: "Codeforces", QuestionID: "1337A", Status: model.StatusAttempted}, Options{}, false},
+		{"other days kept apart", ac, model.Log{Platform: "Codeforces", QuestionID: "1337A", Date: at.AddDate(0, 0, -1)}, Options{KeepAttempts: true}, false},
+		{"other times kept apart", ac, model.Log{Platform: "Codeforces", QuestionID: "1337A", Date: at.Add(time.Hour)}, Options{KeepAttempts: true}, false},
+		{"same minute", ac, model.Log{Platform: "Codeforces", QuestionID: "1337A", Date: at.Add(30 * time.Second)}, Options{KeepAttempts: true}, true},
+	}
+	for _, tt := range tests {
+		if got := tt.opts.dedupeKey(tt.a) == tt.opts.dedupeKey(tt.b); got != tt.equal {
+			t.Errorf("%s: keys equal = %v, want %v", tt.name, got, tt.equal)
+		}
+	}
+}
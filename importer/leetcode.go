@@ -0,0 +1,95 @@
+package importer
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+
+	"github.com/Harschmann/Todo-/model"
+	"github.com/Harschmann/Todo-/platform"
+)
+
+// leetcodeSubmissions mirrors the JSON returned by
+// https://leetcode.com/api/submissions/?offset=0&limit=20.
+type leetcodeSubmissions struct {
+	SubmissionsDump []leetcodeSubmission `json:"submissions_dump"`
+}
+
+type leetcodeSubmission struct {
+	ID            json.Number `json:"id"`
+	Title         string      `json:"title"`
+	TitleSlug     string      `json:"title_slug"`
+	StatusDisplay string      `json:"status_display"`
+	Lang          string      `json:"lang"`
+	Timestamp     unixSeconds `json:"timestamp"`
+}
+
+// unixSeconds accepts a Unix timestamp written either as a number or a string.
+type unixSeconds int64
+
+func (u *unixSeconds) UnmarshalJSON(data []byte) error {
+	n, err := strconv.ParseInt(string(bytes.Trim(data, `"`)), 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid timestamp %s", data)
+	}
+	*u = unixSeconds(n)
+	return nil
+}
+
+// ParseLeetCode turns the accepted submissions of a saved submission list into
+// log entries. Both the API's {"submissions_dump": [...]} object and a plain
+// array of submissions (e.g. several pages merged together) are accepted.
+func ParseLeetCode(r io.Reader) ([]Entry, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	var subs []leetcodeSubmission
+	if trimmed := bytes.TrimSpace(data); len(trimmed) > 0 && trimmed[0] == '[' {
+		err = json.Unmarshal(trimmed, &subs)
+	} else {
+		var dump leetcodeSubmissions
+		err = json.Unmarshal(trimmed, &dump)
+		subs = dump.SubmissionsDump
+	}
+	if err != nil {
+		return nil, fmt.Errorf("could not parse LeetCode submissions: %w", err)
+	}
+
+	var entries []Entry
+	for _, sub := range subs {
+		if sub.StatusDisplay != "Accepted" {
+			continue
+		}
+		slug := sub.TitleSlug
+		if slug == "" {
+			slug = sub.Title
+		}
+		ref, err := platform.Resolve("LeetCode", slug)
+		if err != nil {
+			entries = append(entries, invalidEntry("LeetCode", slug, err))
+			continue
+		}
+		entries = append(entries, Entry{
+			Log: model.Log{
+				QuestionID: ref.ID,
+				Platform:   "LeetCode",
+				Topic:      topicForTags(nil),
+				Difficulty: "Unrated",
+				Status:     model.StatusAccepted,
+				Notes:      fmt.Sprintf("Imported from LeetCode submission %s (%s).", sub.ID, sub.Lang),
+				Date:       time.Unix(int64(sub.Timestamp), 0),
+			},
+			Problem: model.Problem{
+				Platform:  "LeetCode",
+				ProblemID: ref.ID,
+				Title:     sub.Title,
+				URL:       ref.URL,
+			},
+		})
+	}
+	return entries, nil
+}
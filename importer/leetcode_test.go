@@ -0,0 +1,56 @@
+package importer
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseLeetCode(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+	}{
+		{"API object", `{"submissions_dump": [
+			{"id": 11, "title": "Two Sum", "title_slug": "two-sum", "status_display": "Accepted", "lang": "cpp", "timestamp": 1700000000},
+			{"id": 12, "title": "Two Sum", "title_slug": "two-sum", "status_display": "Wrong Answer", "lang": "cpp", "timestamp": 1699999000}
+		]}`},
+		{"plain array with string timestamps", `[
+			{"id": "11", "title": "Two Sum", "title_slug": "two-sum", "status_display": "Accepted", "lang": "cpp", "timestamp": "1700000000"}
+		]`},
+	}
+	for _, tt := range tests {
+		entries, err := ParseLeetCode(strings.NewReader(tt.in))
+		if err != nil {
+			t.Errorf("%s: %v", tt.name, err)
+			continue
+		}
+		if len(entries) != 1 {
+			t.Errorf("%s: got %d entries, want 1", tt.name, len(entries))
+			continue
+		}
+		e := entries[0]
+		if e.Err != nil || e.Log.QuestionID != "two-sum" || e.Log.Platform != "LeetCode" || !e.Log.Date.Equal(time.Unix(1700000000, 0)) {
+			t.Errorf("%s: entry = %+v", tt.name, e)
+		}
+		if e.Log.Notes != "Imported from LeetCode submission 11 (cpp)." || e.Problem.Title != "Two Sum" || e.Problem.URL == "" {
+			t.Errorf("%s: notes %q, problem %+v", tt.name, e.Log.Notes, e.Problem)
+		}
+	}
+}
+
+func TestParseLeetCodeInvalid(t *testing.T) {
+	entries, err := ParseLeetCode(strings.NewReader(`[{"id": 1, "title": "1. Two Sum", "title_slug": "1", "status_display": "Accepted", "timestamp": 1}]`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 1 || entries[0].Err == nil || entries[0].Log.QuestionID != "1" {
+		t.Errorf("entries = %+v, want one invalid entry", entries)
+	}
+
+	for _, in := range []string{`{"submissions_dump": [{"timestamp": "soon"}]}`, `[`} {
+		if _, err := ParseLeetCode(strings.NewReader(in)); err == nil {
+			t.Errorf("ParseLeetCode(%q) succeeded, want an error", in)
+		}
+	}
+}
@@ -0,0 +1,236 @@
+package importer
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Harschmann/Todo-/model"
+	"github.com/Harschmann/Todo-/platform"
+	"github.com/Harschmann/Todo-/utils"
+	"github.com/xuri/excelize/v2"
+)
+
+// Log fields a spreadsheet column can be mapped to.
+const (
+	FieldDate       = "date"
+	FieldPlatform   = "platform"
+	FieldQuestionID = "question id"
+	FieldTopic      = "topic"
+	FieldDifficulty = "difficulty"
+	FieldStatus     = "status"
+	FieldTimeSpent  = "time spent"
+	FieldNotes      = "notes"
+)
+
+// headerAliases maps lower-cased header names to fields. It covers the headers
+// written by the Excel export plus common alternatives.
+var headerAliases = map[string]string{
+	"date":              FieldDate,
+	"solved on":         FieldDate,
+	"day":               FieldDate,
+	"platform":          FieldPlatform,
+	"site":              FieldPlatform,
+	"judge":             FieldPlatform,
+	"question id":       FieldQuestionID,
+	"question":          FieldQuestionID,
+	"problem":           FieldQuestionID,
+	"problem id":        FieldQuestionID,
+	"id":                FieldQuestionID,
+	"topic":             FieldTopic,
+	"tag":               FieldTopic,
+	"difficulty":        FieldDifficulty,
+	"rating":            FieldDifficulty,
+	"status":            FieldStatus,
+	"verdict":           FieldStatus,
+	"time spent (mins)": FieldTimeSpent,
+	"time spent":        FieldTimeSpent,
+	"time":              FieldTimeSpent,
+	"minutes":           FieldTimeSpent,
+	"notes":             FieldNotes,
+	"note":              FieldNotes,
+	"comments":          FieldNotes,
+}
+
+// ColumnMapping maps log fields to spreadsheet header names.
+type ColumnMapping map[string]string
+
+// ParseColumnMapping parses a spec such as "question id=Problem,date=Solved On".
+// Field names may also be given as their export headers, e.g. "Time Spent (mins)".
+func ParseColumnMapping(spec string) (ColumnMapping, error) {
+	mapping := ColumnMapping{}
+	if strings.TrimSpace(spec) == "" {
+		return mapping, nil
+	}
+	for _, pair := range strings.Split(spec, ",") {
+		field, header, ok := strings.Cut(pair, "=")
+		if !ok {
+			return nil, fmt.Errorf("invalid column mapping %q (want field=Header)", pair)
+		}
+		name, ok := headerAliases[strings.ToLower(strings.TrimSpace(field))]
+		if !ok {
+			return nil, fmt.Errorf("unknown log field %q", field)
+		}
+		mapping[name] = strings.TrimSpace(header)
+	}
+	return mapping, nil
+}
+
+// columns resolves each field to a column index, preferring the explicit
+// mapping and falling back to recognized header names.
+func (m ColumnMapping) columns(headers []string) (map[string]int, error) {
+	cols := make(map[string]int)
+	for i, h := range headers {
+		if field, ok := headerAliases[strings.ToLower(strings.TrimSpace(h))]; ok {
+			if _, taken := cols[field]; !taken {
+				cols[field] = i
+			}
+		}
+	}
+	for field, header := range m {
+		found := false
+		for i, h := range headers {
+			if strings.EqualFold(strings.TrimSpace(h), header) {
+				cols[field] = i
+				found = true
+				break
+			}
+		}
+		if !found {
+			return nil, fmt.Errorf("no column named %q for %s", header, field)
+		}
+	}
+	if _, ok := cols[FieldQuestionID]; !ok {
+		return nil, fmt.Errorf("no question ID column found in headers %q; map one with -map \"question id=<header>\"", headers)
+	}
+	// Without dates every row would be logged now, counting towards today's
+	// goals and streak.
+	if _, ok := cols[FieldDate]; !ok {
+		return nil, fmt.Errorf("no date column found in headers %q; map one with -map \"date=<header>\"", headers)
+	}
+	return cols, nil
+}
+
+// SpreadsheetParser returns a parser for "csv" or "xlsx" files using the given
+// column mapping. The first row must hold the headers.
+func SpreadsheetParser(format string, mapping ColumnMapping) (func(r io.Reader) ([]Entry, error), error) {
+	var readRows func(r io.Reader) ([][]string, error)
+	switch format {
+	case "csv":
+		readRows = readCSV
+	case "xlsx":
+		readRows = readXLSX
+	default:
+		return nil, fmt.Errorf("unknown spreadsheet format %q", format)
+	}
+	return func(r io.Reader) ([]Entry, error) {
+		rows, err := readRows(r)
+		if err != nil {
+			return nil, err
+		}
+		return parseRows(rows, mapping)
+	}, nil
+}
+
+func readCSV(r io.Reader) ([][]string, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	rows, err := reader.ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("could not read CSV: %w", err)
+	}
+	return rows, nil
+}
+
+// readXLSX reads the "Logs" sheet written by the export, or the active sheet.
+func readXLSX(r io.Reader) ([][]string, error) {
+	f, err := excelize.OpenReader(r)
+	if err != nil {
+		return nil, fmt.Errorf("could not open workbook: %w", err)
+	}
+	defer f.Close()
+	sheet := f.GetSheetName(f.GetActiveSheetIndex())
+	if idx, err := f.GetSheetIndex("Logs"); err == nil && idx >= 0 {
+		sheet = "Logs"
+	}
+	rows, err := f.GetRows(sheet)
+	if err != nil {
+		return nil, fmt.Errorf("could not read sheet %q: %w", sheet, err)
+	}
+	return rows, nil
+}
+
+func parseRows(rows [][]string, mapping ColumnMapping) ([]Entry, error) {
+	if len(rows) == 0 {
+		return nil, nil
+	}
+	cols, err := mapping.columns(rows[0])
+	if err != nil {
+		return nil, err
+	}
+
+	var entries []Entry
+	for n, row := range rows[1:] {
+		cell := func(field string) string {
+			i, ok := cols[field]
+			if !ok || i >= len(row) {
+				return ""
+			}
+			return strings.TrimSpace(row[i])
+		}
+		if cell(FieldQuestionID) == "" {
+			continue
+		}
+		logEntry := model.Log{
+			QuestionID: cell(FieldQuestionID),
+			Platform:   cell(FieldPlatform),
+			Topic:      cell(FieldTopic),
+			Difficulty: cell(FieldDifficulty),
+			Status:     cell(FieldStatus),
+			Notes:      cell(FieldNotes),
+		}
+		if s := cell(FieldTimeSpent); s != "" {
+			t, err := strconv.Atoi(strings.TrimSuffix(strings.TrimSpace(s), "m"))
+			if err != nil {
+				return nil, fmt.Errorf("row %d: invalid time spent %q", n+2, s)
+			}
+			logEntry.TimeSpent = t
+		}
+		if cell(FieldDate) == "" {
+			return nil, fmt.Errorf("row %d: no date", n+2)
+		}
+		date, err := parseCellDate(cell(FieldDate))
+		if err != nil {
+			return nil, fmt.Errorf("row %d: %w", n+2, err)
+		}
+		logEntry.Date = date
+		if ref, err := platform.Resolve(logEntry.Platform, logEntry.QuestionID); err == nil {
+			logEntry.QuestionID = ref.ID
+		}
+		entries = append(entries, Entry{
+			Log:     logEntry,
+			Problem: model.Problem{Platform: logEntry.Platform, ProblemID: logEntry.QuestionID},
+		})
+	}
+	return entries, nil
+}
+
+// parseCellDate accepts the export's dates, RFC 3339 timestamps and raw Excel date serials.
+func parseCellDate(s string) (time.Time, error) {
+	if t, err := utils.ParseDate(s); err == nil {
+		return t, nil
+	}
+	if t, err := time.Parse(time.RFC3339, s); err == nil {
+		return t, nil
+	}
+	if serial, err := strconv.ParseFloat(s, 64); err == nil {
+		t, err := excelize.ExcelDateToTime(serial, false)
+		if err == nil {
+			return time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second(), 0, time.Local), nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("invalid date %q", s)
+}
@@ -0,0 +1,136 @@
+package importer
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseColumnMapping(t *testing.T) {
+	mapping, err := ParseColumnMapping("question id=Problem Code, Date = Solved On,Time Spent (mins)=Mins")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := ColumnMapping{FieldQuestionID: "Problem Code", FieldDate: "Solved On", FieldTimeSpent: "Mins"}
+	if len(mapping) != len(want) {
+		t.Fatalf("mapping = %v, want %v", mapping, want)
+	}
+	for field, header := range want {
+		if mapping[field] != header {
+			t.Errorf("mapping[%q] = %q, want %q", field, mapping[field], header)
+		}
+	}
+
+	for _, spec := range []string{"question id", "colour=Red"} {
+		if _, err := ParseColumnMapping(spec); err == nil {
+			t.Errorf("ParseColumnMapping(%q) succeeded, want an error", spec)
+		}
+	}
+}
+
+func TestColumns(t *testing.T) {
+	headers := []string{"Solved On", "Problem", "Site", "Notes", "Minutes"}
+	cols, err := ColumnMapping{FieldDate: "solved on"}.columns(headers)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]int{FieldDate: 0, FieldQuestionID: 1, FieldPlatform: 2, FieldNotes: 3, FieldTimeSpent: 4}
+	for field, i := range want {
+		if got, ok := cols[field]; !ok || got != i {
+			t.Errorf("column of %q = %d, %v, want %d", field, got, ok, i)
+		}
+	}
+
+	tests := []struct {
+		name    string
+		mapping ColumnMapping
+		headers []string
+	}{
+		{"no question ID", nil, []string{"Date", "Topic"}},
+		{"no date", nil, []string{"Problem", "Minutes"}},
+		{"mapped header missing", ColumnMapping{FieldTopic: "Category"}, []string{"Date", "Problem"}},
+	}
+	for _, tt := range tests {
+		if _, err := tt.mapping.columns(tt.headers); err == nil {
+			t.Errorf("%s: succeeded, want an error", tt.name)
+		}
+	}
+}
+
+func TestSpreadsheetCSV(t *testing.T) {
+	// The CSV export's own layout.
+	in := "Date,Platform,Question ID,Topic,Difficulty,Status,Time Spent (mins),Notes\n" +
+		"2026-03-11 20:15,Codeforces,1337a,DP,1600,Attempted,45,\"first try,\nwrong idea\"\n" +
+		"2026-03-11 21:30,Codeforces,1337A,DP,1600,AC,30m,\n" +
+		",,,,,,,\n" +
+		"2026-03-12,Gym,Custom Problem,Math,Hard,AC,10,\n"
+	parse, err := SpreadsheetParser("csv", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	entries, err := parse(strings.NewReader(in))
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Rows without a question ID are skipped.
+	if len(entries) != 3 {
+		t.Fatalf("got %d entries, want 3: %+v", len(entries), entries)
+	}
+
+	first := entries[0].Log
+	if first.QuestionID != "1337A" || first.Status != "Attempted" || first.TimeSpent != 45 || first.Notes != "first try,\nwrong idea" {
+		t.Errorf("first entry = %+v", first)
+	}
+	if want := time.Date(2026, 3, 11, 20, 15, 0, 0, time.Local); !first.Date.Equal(want) {
+		t.Errorf("first date = %v, want %v", first.Date, want)
+	}
+	if second := entries[1].Log; second.TimeSpent != 30 || second.Date.Hour() != 21 {
+		t.Errorf("second entry = %+v", second)
+	}
+	// A date without a time starts the day; other platforms keep their IDs.
+	third := entries[2].Log
+	if want := time.Date(2026, 3, 12, 0, 0, 0, 0, time.Local); !third.Date.Equal(want) || third.QuestionID != "Custom Problem" {
+		t.Errorf("third entry = %+v", third)
+	}
+}
+
+func TestSpreadsheetRowErrors(t *testing.T) {
+	parse, err := SpreadsheetParser("csv", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name, in string
+	}{
+		{"missing date", "Date,Problem\n,1337A\n"},
+		{"invalid date", "Date,Problem\nsoon,1337A\n"},
+		{"invalid time spent", "Date,Problem,Minutes\n2026-03-11,1337A,an hour\n"},
+	}
+	for _, tt := range tests {
+		_, err := parse(strings.NewReader(tt.in))
+		if err == nil || !strings.Contains(err.Error(), "row 2") {
+			t.Errorf("%s: error %v, want one for row 2", tt.name, err)
+		}
+	}
+
+	if _, err := SpreadsheetParser("ods", nil); err == nil {
+		t.Error("SpreadsheetParser(ods) succeeded, want an error")
+	}
+}
+
+func TestParseCellDate(t *testing.T) {
+	tests := []struct {
+		in   string
+		want time.Time
+	}{
+		{"2026-03-11 20:15", time.Date(2026, 3, 11, 20, 15, 0, 0, time.Local)},
+		{"2026-03-11T20:15:00Z", time.Date(2026, 3, 11, 20, 15, 0, 0, time.UTC)},
+		{"46092.5", time.Date(2026, 3, 11, 12, 0, 0, 0, time.Local)},
+	}
+	for _, tt := range tests {
+		got, err := parseCellDate(tt.in)
+		if err != nil || !got.Equal(tt.want) {
+			t.Errorf("parseCellDate(%q) = %v, %v, want %v", tt.in, got, err, tt.want)
+		}
+	}
+}
@@ -0,0 +1,80 @@
+package importer
+
+// topicPriority orders the form's topics from most to least specific, so a
+// problem tagged both "greedy" and "dp" is filed under DP.
+var topicPriority = []string{
+	"DP", "Graphs", "Data Structures", "Strings", "Binary Search", "Two Pointers",
+	"Bit Manipulation", "Game Theory", "Math", "Greedy", "Implementation", "Ad-Hoc",
+}
+
+// tagTopics maps platform tags onto the form's topics.
+var tagTopics = map[string]string{
+	"dp":                        "DP",
+	"dynamic programming":       "DP",
+	"memoization":               "DP",
+	"graphs":                    "Graphs",
+	"graph":                     "Graphs",
+	"dfs and similar":           "Graphs",
+	"depth-first search":        "Graphs",
+	"breadth-first search":      "Graphs",
+	"shortest paths":            "Graphs",
+	"trees":                     "Graphs",
+	"tree":                      "Graphs",
+	"dsu":                       "Graphs",
+	"union find":                "Graphs",
+	"graph matchings":           "Graphs",
+	"flows":                     "Graphs",
+	"topological sort":          "Graphs",
+	"data structures":           "Data Structures",
+	"segment tree":              "Data Structures",
+	"binary indexed tree":       "Data Structures",
+	"heap (priority queue)":     "Data Structures",
+	"stack":                     "Data Structures",
+	"queue":                     "Data Structures",
+	"hash table":                "Data Structures",
+	"linked list":               "Data Structures",
+	"strings":                   "Strings",
+	"string":                    "Strings",
+	"hashing":                   "Strings",
+	"string suffix structures":  "Strings",
+	"binary search":             "Binary Search",
+	"ternary search":            "Binary Search",
+	"two pointers":              "Two Pointers",
+	"sliding window":            "Two Pointers",
+	"bitmasks":                  "Bit Manipulation",
+	"bit manipulation":          "Bit Manipulation",
+	"games":                     "Game Theory",
+	"game theory":               "Game Theory",
+	"math":                      "Math",
+	"number theory":             "Math",
+	"combinatorics":             "Math",
+	"probabilities":             "Math",
+	"geometry":                  "Math",
+	"matrices":                  "Math",
+	"fft":                       "Math",
+	"chinese remainder theorem": "Math",
+	"greedy":                    "Greedy",
+	"implementation":            "Implementation",
+	"brute force":               "Implementation",
+	"sortings":                  "Implementation",
+	"sorting":                   "Implementation",
+	"simulation":                "Implementation",
+	"constructive algorithms":   "Ad-Hoc",
+	"interactive":               "Ad-Hoc",
+}
+
+// topicForTags picks the most specific known topic among the tags, or Ad-Hoc.
+func topicForTags(tags []string) string {
+	found := make(map[string]bool)
+	for _, tag := range tags {
+		if topic, ok := tagTopics[tag]; ok {
+			found[topic] = true
+		}
+	}
+	for _, topic := range topicPriority {
+		if found[topic] {
+			return topic
+		}
+	}
+	return "Ad-Hoc"
+}
@@ -0,0 +1,16 @@
+package model
+
+import "time"
+
+// Contest is a rated round such as a Codeforces Div. 2 or an AtCoder ABC.
+// Logs are attached to it through Log.ContestID.
+type Contest struct {
+	ID          int
+	Platform    string
+	Name        string
+	Date        time.Time
+	Duration    int // Minutes
+	Rank        int
+	RatingDelta int
+	NewRating   int // Optional: rating after the contest, 0 if unknown
+}
@@ -0,0 +1,40 @@
+package model
+
+import (
+	"fmt"
+	"time"
+)
+
+// Goal metrics and periods understood by the goal evaluator.
+const (
+	MetricProblems = "problems"
+	MetricMinutes  = "minutes"
+
+	PeriodDay   = "day"
+	PeriodWeek  = "week"
+	PeriodMonth = "month"
+)
+
+// Goal is a practice target such as "3 problems per day" or
+// "5 Graphs problems this month".
+type Goal struct {
+	ID       int
+	Metric   string // MetricProblems or MetricMinutes
+	Period   string // PeriodDay, PeriodWeek or PeriodMonth
+	Target   int
+	Topic    string // Optional: only count logs with this topic
+	Platform string // Optional: only count logs from this platform
+	Created  time.Time
+}
+
+// String describes the goal in plain words, e.g. "5 Graphs problems per month".
+func (g Goal) String() string {
+	what := g.Metric
+	if g.Topic != "" {
+		what = g.Topic + " " + what
+	}
+	if g.Platform != "" {
+		what += " on " + g.Platform
+	}
+	return fmt.Sprintf("%d %s per %s", g.Target, what, g.Period)
+}
@@ -0,0 +1,20 @@
+package model
+
+import "time"
+
+// TrashedLog is a deleted log kept so it can be restored, together with the
+// solutions that were attached to it.
+type TrashedLog struct {
+	ID        int
+	Log       Log
+	Solutions []Solution
+	Deleted   time.Time
+}
+
+// Revision is the state of a log before one of its edits.
+type Revision struct {
+	ID      int
+	LogDate time.Time // The log's current date, which moves with it
+	Log     Log
+	Changed time.Time
+}
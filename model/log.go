@@ -1,6 +1,32 @@
 package model
 
-import "time"
+import (
+	"strings"
+	"time"
+
+	"github.com/Harschmann/Todo-/platform"
+)
+
+// Log statuses. Logs saved before statuses existed have an empty Status and
+// are treated as StatusAccepted.
+const (
+	StatusAccepted  = "AC"
+	StatusAttempted = "Attempted" // Submitted but got WA, TLE, etc.
+	StatusEditorial = "Editorial" // Solved only after reading the editorial
+	StatusUpsolve   = "Upsolve"   // Not solved yet, to come back to later
+)
+
+// Statuses lists every status in the order they are offered in the form.
+var Statuses = []string{StatusAccepted, StatusAttempted, StatusEditorial, StatusUpsolve}
+
+// Platforms and Topics list the choices offered in the form.
+var (
+	Platforms = []string{"Codeforces", "LeetCode", "AtCoder", "HackerRank", "CSES"}
+	Topics    = []string{
+		"Ad-Hoc", "Binary Search", "Bit Manipulation", "Data Structures", "DP", "Game Theory",
+		"Graphs", "Greedy", "Implementation", "Math", "Strings", "Two Pointers",
+	}
+)
 
 type Log struct {
 	ID              string // A unique ID for each entry (e.g. a UUID)
@@ -8,8 +34,24 @@ type Log struct {
 	Platform        string
 	Topic           string
 	Difficulty      string
+	Status          string
 	TimeSpent       int
 	Notes           string
 	Date            time.Time
+	ContestID       int // Optional: the contest this log was solved in
 	CalendarEventID string // ADDED: To store the Google Calendar event ID
 }
+
+// ProblemKey identifies the problem a log is about, so repeated logs of the
+// same problem can be grouped together even if the ID was typed differently.
+func (l Log) ProblemKey() string {
+	return strings.ToLower(strings.TrimSpace(l.Platform)) + ":" + strings.ToLower(platform.NormalizeID(l.Platform, l.QuestionID))
+}
+
+// EffectiveStatus returns the log's status, defaulting to StatusAccepted.
+func (l Log) EffectiveStatus() string {
+	if l.Status == "" {
+		return StatusAccepted
+	}
+	return l.Status
+}
@@ -0,0 +1,16 @@
+package model
+
+import "time"
+
+// Problem is a catalog entry for a single problem on a platform. Logs refer to
+// it through Log.ProblemKey, so every log of the same problem is an attempt.
+type Problem struct {
+	Key       string
+	Platform  string
+	ProblemID string
+	Title     string
+	URL       string
+	Rating    int
+	Tags      []string
+	Added     time.Time
+}
@@ -0,0 +1,16 @@
+package model
+
+import "time"
+
+// Review is the spaced-repetition state of a solved problem.
+type Review struct {
+	ProblemKey   string
+	Platform     string
+	QuestionID   string
+	Topic        string
+	EaseFactor   float64
+	Interval     int // Days until the next review
+	Repetitions  int // Successful reviews in a row
+	Due          time.Time
+	LastReviewed time.Time
+}
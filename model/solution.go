@@ -0,0 +1,14 @@
+package model
+
+import "time"
+
+// Solution is a source file attached to a log. It is linked to the log
+// through LogDate, the same timestamp the log is stored under.
+type Solution struct {
+	ID       int
+	LogDate  time.Time
+	Language string // A name or alias the highlighter knows, e.g. "C++" or "python"
+	FileName string
+	Code     string
+	Added    time.Time
+}
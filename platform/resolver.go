@@ -0,0 +1,192 @@
+package platform
+
+import (
+	"fmt"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Ref is a validated problem reference on a platform.
+type Ref struct {
+	Platform string
+	ID       string // Canonical form, e.g. "1337A", "two-sum", "abc138_a" or "1068"
+	URL      string
+}
+
+// resolver validates and normalizes a raw question ID for one platform.
+type resolver func(raw string) (Ref, error)
+
+var resolvers = map[string]resolver{
+	"codeforces": resolveCodeforces,
+	"leetcode":   resolveLeetCode,
+	"atcoder":    resolveAtCoder,
+	"cses":       resolveCSES,
+	"hackerrank": resolveHackerRank,
+}
+
+// Resolve validates a question ID for the named platform and derives its
+// canonical ID and URL. Pasted problem URLs are accepted too. Platforms
+// without a resolver accept any non-empty ID and have no URL.
+func Resolve(platformName, raw string) (Ref, error) {
+	raw = strings.TrimSpace(raw)
+	if raw == "" {
+		return Ref{}, fmt.Errorf("question ID is empty")
+	}
+	resolve, ok := resolvers[strings.ToLower(strings.TrimSpace(platformName))]
+	if !ok {
+		return Ref{Platform: platformName, ID: raw}, nil
+	}
+	ref, err := resolve(raw)
+	if err != nil {
+		return Ref{}, fmt.Errorf("invalid %s question ID %q: %w", platformName, raw, err)
+	}
+	ref.Platform = platformName
+	return ref, nil
+}
+
+// NormalizeID returns the canonical ID, or the trimmed input if it does not resolve.
+func NormalizeID(platformName, raw string) string {
+	ref, err := Resolve(platformName, raw)
+	if err != nil {
+		return strings.TrimSpace(raw)
+	}
+	return ref.ID
+}
+
+// urlPath returns the path of a pasted URL on the given host, or "" if raw is not such a URL.
+func urlPath(raw, host string) string {
+	u, err := url.Parse(raw)
+	if err != nil || u.Host == "" || !strings.HasSuffix(u.Host, host) {
+		return ""
+	}
+	return strings.Trim(u.Path, "/")
+}
+
+var (
+	codeforcesID   = regexp.MustCompile(`^(?i)(?:cf)?\s*(\d+)\s*[/\-_ ]?\s*([a-z]\d?)$`)
+	codeforcesPath = regexp.MustCompile(`^(?:problemset/problem|contest|gym)/(\d+)/(?:problem/)?([A-Za-z]\d?)$`)
+)
+
+func resolveCodeforces(raw string) (Ref, error) {
+	var contest, index string
+	if path := urlPath(raw, "codeforces.com"); path != "" {
+		m := codeforcesPath.FindStringSubmatch(path)
+		if m == nil {
+			return Ref{}, fmt.Errorf("not a Codeforces problem URL")
+		}
+		contest, index = m[1], m[2]
+	} else {
+		m := codeforcesID.FindStringSubmatch(raw)
+		if m == nil {
+			return Ref{}, fmt.Errorf("want a contest number and problem index, e.g. 1337A")
+		}
+		contest, index = m[1], m[2]
+	}
+	index = strings.ToUpper(index)
+	id := contest + index
+	n, _ := strconv.Atoi(contest)
+	// Gym contests have six-digit IDs and live under a different path.
+	if n >= 100000 {
+		return Ref{ID: id, URL: fmt.Sprintf("https://codeforces.com/gym/%s/problem/%s", contest, index)}, nil
+	}
+	return Ref{ID: id, URL: fmt.Sprintf("https://codeforces.com/problemset/problem/%s/%s", contest, index)}, nil
+}
+
+var slugPattern = regexp.MustCompile(`^[a-z0-9]+(?:-[a-z0-9]+)*$`)
+
+// slugify turns "Two Sum" or "two_sum" into "two-sum".
+func slugify(raw string) string {
+	var b strings.Builder
+	dash := false
+	for _, r := range strings.ToLower(raw) {
+		switch {
+		case r >= 'a' && r <= 'z', r >= '0' && r <= '9':
+			if dash && b.Len() > 0 {
+				b.WriteByte('-')
+			}
+			dash = false
+			b.WriteRune(r)
+		case r == '\'':
+		default:
+			dash = true
+		}
+	}
+	return b.String()
+}
+
+func resolveLeetCode(raw string) (Ref, error) {
+	slug := raw
+	if path := urlPath(raw, "leetcode.com"); path != "" {
+		parts := strings.Split(path, "/")
+		if len(parts) < 2 || parts[0] != "problems" {
+			return Ref{}, fmt.Errorf("not a LeetCode problem URL")
+		}
+		slug = parts[1]
+	}
+	slug = slugify(slug)
+	if !slugPattern.MatchString(slug) {
+		return Ref{}, fmt.Errorf("want a problem slug, e.g. two-sum")
+	}
+	if _, err := strconv.Atoi(slug); err == nil {
+		return Ref{}, fmt.Errorf("want the problem slug (e.g. two-sum), not its number")
+	}
+	return Ref{ID: slug, URL: fmt.Sprintf("https://leetcode.com/problems/%s/", slug)}, nil
+}
+
+// AtCoder task IDs are the contest plus a task suffix. With a separator the
+// contest can be anything (abc138_a, practice_1, dp_a); without one it must be
+// a numbered contest such as abc138a or abc300ex, so abc300 is not split.
+var (
+	atcoderID        = regexp.MustCompile(`^(?i)([a-z][a-z0-9\-]*)[\s_\-]+([a-z]|ex|\d+)$`)
+	atcoderCompactID = regexp.MustCompile(`^(?i)([a-z]+\d{3})([a-z]|ex)$`)
+)
+
+func resolveAtCoder(raw string) (Ref, error) {
+	if path := urlPath(raw, "atcoder.jp"); path != "" {
+		parts := strings.Split(path, "/")
+		if len(parts) != 4 || parts[0] != "contests" || parts[2] != "tasks" {
+			return Ref{}, fmt.Errorf("not an AtCoder task URL")
+		}
+		return Ref{ID: parts[3], URL: "https://atcoder.jp/" + path}, nil
+	}
+	m := atcoderID.FindStringSubmatch(raw)
+	if m == nil {
+		m = atcoderCompactID.FindStringSubmatch(raw)
+	}
+	if m == nil {
+		return Ref{}, fmt.Errorf("want a task id, e.g. abc138_a")
+	}
+	contest := strings.ToLower(m[1])
+	id := contest + "_" + strings.ToLower(m[2])
+	return Ref{ID: id, URL: fmt.Sprintf("https://atcoder.jp/contests/%s/tasks/%s", contest, id)}, nil
+}
+
+func resolveCSES(raw string) (Ref, error) {
+	id := raw
+	if path := urlPath(raw, "cses.fi"); path != "" {
+		parts := strings.Split(path, "/")
+		id = parts[len(parts)-1]
+	}
+	if _, err := strconv.Atoi(id); err != nil {
+		return Ref{}, fmt.Errorf("want a task number, e.g. 1068")
+	}
+	return Ref{ID: id, URL: "https://cses.fi/problemset/task/" + id}, nil
+}
+
+func resolveHackerRank(raw string) (Ref, error) {
+	slug := raw
+	if path := urlPath(raw, "hackerrank.com"); path != "" {
+		parts := strings.Split(path, "/")
+		if len(parts) < 2 || parts[0] != "challenges" {
+			return Ref{}, fmt.Errorf("not a HackerRank challenge URL")
+		}
+		slug = parts[1]
+	}
+	slug = slugify(slug)
+	if !slugPattern.MatchString(slug) {
+		return Ref{}, fmt.Errorf("want a challenge slug, e.g. simple-array-sum")
+	}
+	return Ref{ID: slug, URL: fmt.Sprintf("https://www.hackerrank.com/challenges/%s/problem", slug)}, nil
+}
@@ -0,0 +1,57 @@
+package platform
+
+import "testing"
+
+func TestResolve(t *testing.T) {
+	tests := []struct {
+		platform, raw string
+		id, url       string
+		wantErr       bool
+	}{
+		{platform: "Codeforces", raw: "1337A", id: "1337A", url: "https://codeforces.com/problemset/problem/1337/A"},
+		{platform: "Codeforces", raw: "cf 1337 a", id: "1337A", url: "https://codeforces.com/problemset/problem/1337/A"},
+		{platform: "Codeforces", raw: "1520/b1", id: "1520B1", url: "https://codeforces.com/problemset/problem/1520/B1"},
+		{platform: "Codeforces", raw: "https://codeforces.com/contest/1337/problem/C", id: "1337C", url: "https://codeforces.com/problemset/problem/1337/C"},
+		{platform: "Codeforces", raw: "102951A", id: "102951A", url: "https://codeforces.com/gym/102951/problem/A"},
+		{platform: "Codeforces", raw: "A", wantErr: true},
+		{platform: "Codeforces", raw: "https://codeforces.com/blog/entry/1", wantErr: true},
+
+		{platform: "AtCoder", raw: "abc138_a", id: "abc138_a", url: "https://atcoder.jp/contests/abc138/tasks/abc138_a"},
+		{platform: "AtCoder", raw: "ABC138A", id: "abc138_a", url: "https://atcoder.jp/contests/abc138/tasks/abc138_a"},
+		{platform: "AtCoder", raw: "abc300 ex", id: "abc300_ex", url: "https://atcoder.jp/contests/abc300/tasks/abc300_ex"},
+		{platform: "AtCoder", raw: "abc300ex", id: "abc300_ex", url: "https://atcoder.jp/contests/abc300/tasks/abc300_ex"},
+		{platform: "AtCoder", raw: "practice_1", id: "practice_1", url: "https://atcoder.jp/contests/practice/tasks/practice_1"},
+		{platform: "AtCoder", raw: "dp_a", id: "dp_a", url: "https://atcoder.jp/contests/dp/tasks/dp_a"},
+		{platform: "AtCoder", raw: "https://atcoder.jp/contests/abc138/tasks/abc138_d", id: "abc138_d", url: "https://atcoder.jp/contests/abc138/tasks/abc138_d"},
+		{platform: "AtCoder", raw: "abc300", wantErr: true},
+		{platform: "AtCoder", raw: "abc30", wantErr: true},
+
+		{platform: "LeetCode", raw: "two-sum", id: "two-sum", url: "https://leetcode.com/problems/two-sum/"},
+		{platform: "LeetCode", raw: "Two Sum", id: "two-sum", url: "https://leetcode.com/problems/two-sum/"},
+		{platform: "LeetCode", raw: "https://leetcode.com/problems/two-sum/description/", id: "two-sum", url: "https://leetcode.com/problems/two-sum/"},
+		{platform: "LeetCode", raw: "1", wantErr: true},
+
+		{platform: "CSES", raw: "1068", id: "1068", url: "https://cses.fi/problemset/task/1068"},
+		{platform: "CSES", raw: "https://cses.fi/problemset/task/1068/", id: "1068", url: "https://cses.fi/problemset/task/1068"},
+		{platform: "CSES", raw: "weird-algorithm", wantErr: true},
+
+		{platform: "Other", raw: " anything ", id: "anything"},
+		{platform: "Codeforces", raw: "  ", wantErr: true},
+	}
+	for _, tt := range tests {
+		ref, err := Resolve(tt.platform, tt.raw)
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("Resolve(%q, %q) = %+v, want an error", tt.platform, tt.raw, ref)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("Resolve(%q, %q): %v", tt.platform, tt.raw, err)
+			continue
+		}
+		if ref.ID != tt.id || ref.URL != tt.url {
+			t.Errorf("Resolve(%q, %q) = %q, %q; want %q, %q", tt.platform, tt.raw, ref.ID, ref.URL, tt.id, tt.url)
+		}
+	}
+}
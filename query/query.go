@@ -0,0 +1,272 @@
+// Package query parses filter expressions such as
+//
+//	topic:dp difficulty:hard date>=2026-01-01 time>60 platform:codeforces
+//
+// into a predicate over logs. Terms are ANDed together. A term is either
+// field<op>value or a bare word, which matches any log whose ID, platform,
+// topic, difficulty, status or notes contain it. Prefix a term with "-" to
+// negate it, separate values with commas to match any of them
+// (topic:dp,greedy), and quote values with spaces (topic:"binary search").
+// Times are minutes or durations such as 1h30m.
+package query
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Harschmann/Todo-/model"
+	"github.com/Harschmann/Todo-/utils"
+)
+
+// Query is a parsed expression. The zero Query matches every log.
+type Query struct {
+	terms []func(model.Log) bool
+}
+
+type fieldKind int
+
+const (
+	textField fieldKind = iota
+	numberField
+	dateField
+)
+
+type field struct {
+	kind fieldKind
+	get  func(model.Log) string // For text fields
+	num  func(model.Log) int    // For number fields
+}
+
+var fields = map[string]field{
+	"platform":   {kind: textField, get: func(l model.Log) string { return l.Platform }},
+	"topic":      {kind: textField, get: func(l model.Log) string { return l.Topic }},
+	"difficulty": {kind: textField, get: func(l model.Log) string { return l.Difficulty }},
+	"status":     {kind: textField, get: func(l model.Log) string { return l.EffectiveStatus() }},
+	"id":         {kind: textField, get: func(l model.Log) string { return l.QuestionID }},
+	"notes":      {kind: textField, get: func(l model.Log) string { return l.Notes }},
+	"time":       {kind: numberField, num: func(l model.Log) int { return l.TimeSpent }},
+	"date":       {kind: dateField},
+}
+
+var fieldAliases = map[string]string{
+	"diff":    "difficulty",
+	"verdict": "status",
+	"problem": "id",
+	"note":    "notes",
+	"minutes": "time",
+	"mins":    "time",
+	"day":     "date",
+}
+
+var termPattern = regexp.MustCompile(`^(-?)([A-Za-z]+)(!=|>=|<=|:|=|>|<)(.*)$`)
+
+// Parse compiles an expression. An empty string matches every log.
+func Parse(s string) (Query, error) {
+	words, err := utils.SplitQuoted(s)
+	if err != nil {
+		return Query{}, err
+	}
+	var q Query
+	for _, word := range words {
+		term, err := parseTerm(word)
+		if err != nil {
+			return Query{}, err
+		}
+		q.terms = append(q.terms, term)
+	}
+	return q, nil
+}
+
+// Match reports whether a log satisfies every term of the query.
+func (q Query) Match(l model.Log) bool {
+	for _, term := range q.terms {
+		if !term(l) {
+			return false
+		}
+	}
+	return true
+}
+
+// Empty reports whether the query has no terms and so matches everything.
+func (q Query) Empty() bool {
+	return len(q.terms) == 0
+}
+
+// Filter returns the logs matching the query, in their original order.
+func (q Query) Filter(logs []model.Log) []model.Log {
+	var matched []model.Log
+	for _, l := range logs {
+		if q.Match(l) {
+			matched = append(matched, l)
+		}
+	}
+	return matched
+}
+
+// Fields lists the field names a query can use.
+func Fields() []string {
+	names := make([]string, 0, len(fields))
+	for name := range fields {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func parseTerm(word string) (func(model.Log) bool, error) {
+	m := termPattern.FindStringSubmatch(word)
+	name := ""
+	if m != nil {
+		name = strings.ToLower(m[2])
+		if alias, ok := fieldAliases[name]; ok {
+			name = alias
+		}
+	}
+	f, ok := fields[name]
+	if m != nil && !ok && !strings.HasPrefix(m[4], "//") {
+		return nil, fmt.Errorf("unknown field %q (want one of %s)", m[2], strings.Join(Fields(), ", "))
+	}
+	if !ok {
+		// Not a field, e.g. a bare word or a URL: match it anywhere.
+		negate := len(word) > 1 && word[0] == '-'
+		if negate {
+			word = word[1:]
+		}
+		text := strings.ToLower(strings.ReplaceAll(word, `"`, ""))
+		return withNegation(negate, func(l model.Log) bool {
+			for _, v := range []string{l.QuestionID, l.Platform, l.Topic, l.Difficulty, l.EffectiveStatus(), l.Notes} {
+				if strings.Contains(strings.ToLower(v), text) {
+					return true
+				}
+			}
+			return false
+		}), nil
+	}
+
+	negate, op, value := m[1] == "-", m[3], strings.ReplaceAll(m[4], `"`, "")
+	if op == "!=" {
+		negate, op = !negate, "="
+	}
+	if op == ":" {
+		op = "="
+	}
+	if value == "" {
+		return nil, fmt.Errorf("%s%s needs a value", m[2], m[3])
+	}
+
+	var match func(model.Log) bool
+	var err error
+	switch f.kind {
+	case textField:
+		match, err = textMatcher(name, f, op, value)
+	case numberField:
+		match, err = numberMatcher(name, f, op, value)
+	case dateField:
+		match, err = dateMatcher(op, value)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return withNegation(negate, match), nil
+}
+
+func withNegation(negate bool, match func(model.Log) bool) func(model.Log) bool {
+	if !negate {
+		return match
+	}
+	return func(l model.Log) bool { return !match(l) }
+}
+
+// textMatcher compares case-insensitively; notes match if they contain the
+// value. Comparisons such as difficulty>=1600 use the number the value starts with.
+func textMatcher(name string, f field, op, value string) (func(model.Log) bool, error) {
+	if op != "=" {
+		n, err := strconv.Atoi(value)
+		if err != nil {
+			return nil, fmt.Errorf("%s%s needs a number (got %q)", name, op, value)
+		}
+		return func(l model.Log) bool {
+			got, ok := leadingNumber(f.get(l))
+			return ok && compare(op, got, n)
+		}, nil
+	}
+	values := strings.Split(strings.ToLower(value), ",")
+	return func(l model.Log) bool {
+		got := strings.ToLower(f.get(l))
+		for _, v := range values {
+			if got == v || (name == "notes" && strings.Contains(got, v)) {
+				return true
+			}
+		}
+		return false
+	}, nil
+}
+
+// leadingNumber parses values like "1600" or "300 pts".
+func leadingNumber(s string) (int, bool) {
+	words := strings.Fields(s)
+	if len(words) == 0 {
+		return 0, false
+	}
+	n, err := strconv.Atoi(words[0])
+	return n, err == nil
+}
+
+func numberMatcher(name string, f field, op, value string) (func(model.Log) bool, error) {
+	var want []int
+	for _, v := range strings.Split(value, ",") {
+		n, err := strconv.Atoi(v)
+		if err != nil {
+			var ok bool
+			if n, ok = utils.ParseDuration(v); !ok {
+				return nil, fmt.Errorf("invalid %s %q (want minutes, e.g. %s>60 or %s>1h30m)", name, value, name, name)
+			}
+		}
+		want = append(want, n)
+	}
+	if len(want) > 1 && op != "=" {
+		return nil, fmt.Errorf("%s%s takes a single value", name, op)
+	}
+	return func(l model.Log) bool {
+		got := f.num(l)
+		for _, n := range want {
+			if compare(op, got, n) {
+				return true
+			}
+		}
+		return false
+	}, nil
+}
+
+// dateMatcher compares whole days, so date<=2026-01-31 includes that day.
+func dateMatcher(op, value string) (func(model.Log) bool, error) {
+	t, err := utils.ParseDate(value)
+	if err != nil {
+		return nil, err
+	}
+	day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.Local)
+	return func(l model.Log) bool {
+		d := l.Date.In(time.Local)
+		logDay := time.Date(d.Year(), d.Month(), d.Day(), 0, 0, 0, 0, time.Local)
+		return compare(op, logDay.Compare(day), 0)
+	}, nil
+}
+
+func compare(op string, a, b int) bool {
+	switch op {
+	case ">":
+		return a > b
+	case ">=":
+		return a >= b
+	case "<":
+		return a < b
+	case "<=":
+		return a <= b
+	default:
+		return a == b
+	}
+}
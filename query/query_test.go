@@ -0,0 +1,77 @@
+package query
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Harschmann/Todo-/model"
+)
+
+func TestParseAndMatch(t *testing.T) {
+	now := time.Now()
+	dp := model.Log{Platform: "Codeforces", QuestionID: "1337A", Topic: "DP", Difficulty: "1600", Status: model.StatusAccepted, TimeSpent: 75, Notes: "prefix sums", Date: now}
+	bs := model.Log{Platform: "LeetCode", QuestionID: "binary-search", Topic: "Binary Search", Difficulty: "Easy", Status: model.StatusAttempted, TimeSpent: 20, Date: now.AddDate(0, 0, -10)}
+
+	tests := []struct {
+		query string
+		want  []bool // Whether dp and bs match
+	}{
+		{"", []bool{true, true}},
+		{"topic:dp", []bool{true, false}},
+		{"TOPIC:DP", []bool{true, false}},
+		{"-topic:dp", []bool{false, true}},
+		{"topic!=dp", []bool{false, true}},
+		{"topic:dp,greedy", []bool{true, false}},
+		{`topic:"binary search"`, []bool{false, true}},
+		{"diff>=1600", []bool{true, false}},
+		{"status:attempted", []bool{false, true}},
+		{"notes:prefix", []bool{true, false}},
+		{"time>60", []bool{true, false}},
+		{"time>1h", []bool{true, false}},
+		{"time<=20m", []bool{false, true}},
+		{"mins>=1h15m", []bool{true, false}},
+		{"date>=-1w", []bool{true, false}},
+		{"date<" + now.Format("2006-01-02"), []bool{false, true}},
+		{"prefix", []bool{true, false}},
+		{"-prefix", []bool{false, true}},
+		{"leetcode binary", []bool{false, true}},
+		{"https://leetcode.com", []bool{false, false}},
+	}
+	for _, tt := range tests {
+		q, err := Parse(tt.query)
+		if err != nil {
+			t.Errorf("Parse(%q): %v", tt.query, err)
+			continue
+		}
+		for i, l := range []model.Log{dp, bs} {
+			if got := q.Match(l); got != tt.want[i] {
+				t.Errorf("Parse(%q).Match(%s) = %v, want %v", tt.query, l.QuestionID, got, tt.want[i])
+			}
+		}
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	tests := []struct {
+		query, want string
+	}{
+		{"topc:dp", `unknown field "topc"`},
+		{"topic:", "needs a value"},
+		{"time>soon", "invalid time"},
+		{"time>1,2", "single value"},
+		{"difficulty>hard", "needs a number"},
+		{"date>=someday", "invalid date"},
+		{`topic:"dp`, ""},
+	}
+	for _, tt := range tests {
+		_, err := Parse(tt.query)
+		if err == nil {
+			t.Errorf("Parse(%q) succeeded, want an error", tt.query)
+			continue
+		}
+		if !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("Parse(%q) = %v, want an error mentioning %q", tt.query, err, tt.want)
+		}
+	}
+}
@@ -0,0 +1,187 @@
+// Package quickadd parses one-line log entries such as
+//
+//	cf 1337A dp hard 45m "used prefix sums"
+//
+// into a model.Log. Words can come in any order: a platform, a topic, a
+// difficulty (easy, medium, hard or a rating such as 1600), a status, the
+// time spent (45m, 1h, 1h30m), notes in quotes and a date prefixed with @
+// (@yesterday, @-2d, @"yesterday 21:30"). The word that is none of these is
+// the question ID. Platforms and topics accept short aliases
+// (cf, lc, dp, bs, ...), and more can be added in config.json.
+package quickadd
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/Harschmann/Todo-/config"
+	"github.com/Harschmann/Todo-/model"
+	"github.com/Harschmann/Todo-/platform"
+	"github.com/Harschmann/Todo-/utils"
+)
+
+var platformAliases = map[string]string{
+	"cf":  "Codeforces",
+	"lc":  "LeetCode",
+	"ac":  "AtCoder",
+	"atc": "AtCoder",
+	"hr":  "HackerRank",
+}
+
+var topicAliases = map[string]string{
+	"adhoc":    "Ad-Hoc",
+	"bs":       "Binary Search",
+	"bsearch":  "Binary Search",
+	"bit":      "Bit Manipulation",
+	"bits":     "Bit Manipulation",
+	"bitmask":  "Bit Manipulation",
+	"ds":       "Data Structures",
+	"game":     "Game Theory",
+	"games":    "Game Theory",
+	"graph":    "Graphs",
+	"impl":     "Implementation",
+	"string":   "Strings",
+	"str":      "Strings",
+	"tp":       "Two Pointers",
+	"2p":       "Two Pointers",
+	"pointers": "Two Pointers",
+}
+
+var difficulties = map[string]string{"easy": "Easy", "medium": "Medium", "med": "Medium", "hard": "Hard"}
+
+// statusWords maps verdicts to statuses; WA, TLE and friends are attempts.
+var statusWords = map[string]string{
+	"ac":        model.StatusAccepted,
+	"solved":    model.StatusAccepted,
+	"wa":        model.StatusAttempted,
+	"tle":       model.StatusAttempted,
+	"mle":       model.StatusAttempted,
+	"re":        model.StatusAttempted,
+	"attempted": model.StatusAttempted,
+	"editorial": model.StatusEditorial,
+	"upsolve":   model.StatusUpsolve,
+	"todo":      model.StatusUpsolve,
+}
+
+var ratingPattern = regexp.MustCompile(`^\d{3,4}$`)
+
+// Parse parses a line typed in one go, where notes and dates with spaces are
+// quoted.
+func Parse(line string) (model.Log, error) {
+	words, err := utils.SplitQuoted(line)
+	if err != nil {
+		return model.Log{}, err
+	}
+	return ParseWords(words)
+}
+
+// ParseWords parses words that are already split, such as command-line
+// arguments, where a word with spaces in it is notes.
+func ParseWords(words []string) (model.Log, error) {
+	l := model.Log{Status: model.StatusAccepted}
+	var notes, rest []string
+	statusSet := false
+	for _, word := range words {
+		lower := strings.ToLower(word)
+		switch {
+		case word == "":
+			continue
+		case strings.HasPrefix(word, "@"):
+			date, err := utils.ParseLogDate(strings.Trim(word[1:], `"`))
+			if err != nil {
+				return model.Log{}, err
+			}
+			l.Date = date
+		case strings.HasPrefix(word, `"`) || strings.ContainsAny(word, " \t\n"):
+			notes = append(notes, strings.Trim(word, `"`))
+		case l.Platform == "" && platformName(lower) != "":
+			// "ac" is AtCoder before any platform and a verdict after one.
+			l.Platform = platformName(lower)
+		case !statusSet && statusWords[lower] != "":
+			l.Status, statusSet = statusWords[lower], true
+		case l.Difficulty == "" && difficulties[lower] != "":
+			l.Difficulty = difficulties[lower]
+		case l.Topic == "" && topicName(lower) != "":
+			l.Topic = topicName(lower)
+		case l.TimeSpent == 0 && isDuration(lower):
+			l.TimeSpent, _ = utils.ParseDuration(lower)
+		default:
+			rest = append(rest, word)
+		}
+	}
+	l.Notes = strings.Join(notes, "\n")
+
+	// What is left is the question ID and maybe a rating, in either order.
+	// A lone number is the ID, as CSES IDs look like ratings.
+	if l.Difficulty == "" && len(rest) > 1 {
+		for i := len(rest) - 1; i >= 0; i-- {
+			if ratingPattern.MatchString(rest[i]) {
+				l.Difficulty = rest[i]
+				rest = append(rest[:i], rest[i+1:]...)
+				break
+			}
+		}
+	}
+	if len(rest) > 0 {
+		l.QuestionID = rest[0]
+	}
+	if len(rest) > 1 {
+		return model.Log{}, fmt.Errorf("did not understand %q", rest[1])
+	}
+
+	var missing []string
+	for _, field := range [][2]string{{"platform", l.Platform}, {"question ID", l.QuestionID}, {"topic", l.Topic}, {"difficulty", l.Difficulty}} {
+		if field[1] == "" {
+			missing = append(missing, field[0])
+		}
+	}
+	if len(missing) > 0 {
+		return l, fmt.Errorf("missing %s", strings.Join(missing, ", "))
+	}
+	ref, err := platform.Resolve(l.Platform, l.QuestionID)
+	if err != nil {
+		return l, err
+	}
+	l.QuestionID = ref.ID
+	return l, nil
+}
+
+// platformName returns the platform a word names, or "".
+func platformName(word string) string {
+	if name, ok := config.Get().PlatformAliases[word]; ok {
+		return name
+	}
+	if name, ok := platformAliases[word]; ok {
+		return name
+	}
+	for _, p := range model.Platforms {
+		if strings.ToLower(p) == word {
+			return p
+		}
+	}
+	return ""
+}
+
+// topicName returns the topic a word names, or "". Multi-word topics can be
+// written with dashes or underscores, e.g. binary-search.
+func topicName(word string) string {
+	if name, ok := config.Get().TopicAliases[word]; ok {
+		return name
+	}
+	if name, ok := topicAliases[word]; ok {
+		return name
+	}
+	normalize := strings.NewReplacer("-", "", "_", "", " ", "")
+	for _, t := range model.Topics {
+		if normalize.Replace(strings.ToLower(t)) == normalize.Replace(word) {
+			return t
+		}
+	}
+	return ""
+}
+
+func isDuration(word string) bool {
+	_, ok := utils.ParseDuration(word)
+	return ok
+}
@@ -0,0 +1,74 @@
+package quickadd
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Harschmann/Todo-/model"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		line string
+		want model.Log
+	}{
+		{`cf 1337A dp hard 45m "used prefix sums"`, model.Log{Platform: "Codeforces", QuestionID: "1337A", Topic: "DP", Difficulty: "Hard", Status: model.StatusAccepted, TimeSpent: 45, Notes: "used prefix sums"}},
+		{"cf dp 1600 1337a", model.Log{Platform: "Codeforces", QuestionID: "1337A", Topic: "DP", Difficulty: "1600", Status: model.StatusAccepted}},
+		{"cf 1337A dp 1600", model.Log{Platform: "Codeforces", QuestionID: "1337A", Topic: "DP", Difficulty: "1600", Status: model.StatusAccepted}},
+		{"lc two-sum bs easy wa 1h30m", model.Log{Platform: "LeetCode", QuestionID: "two-sum", Topic: "Binary Search", Difficulty: "Easy", Status: model.StatusAttempted, TimeSpent: 90}},
+		{"ac abc138_a math med ac 90min", model.Log{Platform: "AtCoder", QuestionID: "abc138_a", Topic: "Math", Difficulty: "Medium", Status: model.StatusAccepted, TimeSpent: 90}},
+		{"hard upsolve binary-search atcoder abc300ex", model.Log{Platform: "AtCoder", QuestionID: "abc300_ex", Topic: "Binary Search", Difficulty: "Hard", Status: model.StatusUpsolve}},
+		{"cses 1068 impl easy", model.Log{Platform: "CSES", QuestionID: "1068", Topic: "Implementation", Difficulty: "Easy", Status: model.StatusAccepted}},
+		{`cf 1337A dp hard "first" "second"`, model.Log{Platform: "Codeforces", QuestionID: "1337A", Topic: "DP", Difficulty: "Hard", Status: model.StatusAccepted, Notes: "first\nsecond"}},
+	}
+	for _, tt := range tests {
+		got, err := Parse(tt.line)
+		if err != nil {
+			t.Errorf("Parse(%q): %v", tt.line, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("Parse(%q) = %+v, want %+v", tt.line, got, tt.want)
+		}
+	}
+}
+
+func TestParseDate(t *testing.T) {
+	l, err := Parse(`cf 1337A dp hard @"yesterday 21:30"`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	y := time.Now().AddDate(0, 0, -1)
+	want := time.Date(y.Year(), y.Month(), y.Day(), 21, 30, 0, 0, time.Local)
+	if !l.Date.Equal(want) {
+		t.Errorf("date = %v, want %v", l.Date, want)
+	}
+	if l, err := Parse("cf 1337A dp hard"); err != nil || !l.Date.IsZero() {
+		t.Errorf("Parse without a date = %v, %v; want a zero date", l.Date, err)
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	tests := []struct {
+		line, want string
+	}{
+		{"cf 1337A dp", "missing difficulty"},
+		{"1337A dp hard", "missing platform"},
+		{"cf dp hard", "missing question ID"},
+		{"cf 1337A dp hard banana", `did not understand "banana"`},
+		{"cf notanid dp hard", "invalid Codeforces question ID"},
+		{"cf 1337A dp hard @tomorrow", "in the future"},
+		{`cf 1337A dp hard "unterminated`, ""},
+	}
+	for _, tt := range tests {
+		_, err := Parse(tt.line)
+		if err == nil {
+			t.Errorf("Parse(%q) succeeded, want an error", tt.line)
+			continue
+		}
+		if !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("Parse(%q) = %v, want an error mentioning %q", tt.line, err, tt.want)
+		}
+	}
+}
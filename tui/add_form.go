@@ -9,10 +9,17 @@ import (
 	"time"
 
 	"github.com/Harschmann/Todo-/calendar"
+	"github.com/Harschmann/Todo-/config"
+	"github.com/Harschmann/Todo-/core"
 	"github.com/Harschmann/Todo-/db"
 	"github.com/Harschmann/Todo-/model"
+	"github.com/Harschmann/Todo-/platform"
+	"github.com/Harschmann/Todo-/utils"
 	"github.com/charmbracelet/bubbles/key"
 	"github.com/charmbracelet/bubbles/list"
+	"github.com/charmbracelet/bubbles/progress"
+	"github.com/charmbracelet/bubbles/table"
+	"github.com/charmbracelet/bubbles/textarea"
 	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
@@ -26,12 +33,27 @@ const (
 	viewPlatform
 	viewTopic
 	viewDifficulty
+	viewStatus
 	viewQuestionID
 	viewTime
+	viewDate
 	viewNotes
 	viewLogs
 	viewLogDetails
 	viewConfirmDelete
+	viewGoals
+	viewReview
+	viewProblems
+	viewContestPicker
+	viewContests
+	viewContestDetails
+	viewSolution
+	viewSearch
+	viewBulkActions
+	viewBulkValue
+	viewBulkConfirm
+	viewQuickAdd
+	viewDuplicate
 )
 
 // --- STYLES ---
@@ -78,7 +100,16 @@ func (l logListItem) FilterValue() string {
 }
 func (l logListItem) Title() string { return l.QuestionID }
 func (l logListItem) Description() string {
-	return fmt.Sprintf("%s | %s | %s | %s", l.Platform, l.Topic, l.Difficulty, l.Date.Format("2006-01-02"))
+	return fmt.Sprintf("%s | %s | %s | %s | %s", l.Platform, l.Topic, l.Difficulty, model.Log(l).EffectiveStatus(), l.Date.Format("2006-01-02"))
+}
+
+func newLogDelegate() list.DefaultDelegate {
+	logDelegate := list.NewDefaultDelegate()
+	logDelegate.Styles.SelectedTitle = selectedItemStyle
+	logDelegate.Styles.SelectedDesc = descriptionStyle
+	logDelegate.Styles.NormalDesc = descriptionStyle
+	logDelegate.Styles.DimmedDesc = descriptionStyle
+	return logDelegate
 }
 
 // --- MODEL ---
@@ -86,17 +117,48 @@ type formModel struct {
 	currentView     currentView
 	logEntry        model.Log
 	selectedLog     model.Log
+	detailsParent   currentView
+	selectedURL     string
+	selectedNotes   string
+	solutions       []model.Solution
+	solutionIndex   int
+	attemptNumber   int
+	attemptCount    int
 	mainMenu        list.Model
 	platforms       list.Model
 	topics          list.Model
 	difficulty      list.Model
+	statuses        list.Model
 	logsList        list.Model
+	logsTable       table.Model
+	tableLogs       []model.Log // The logs in the table, in display order
+	tableLayout     bool
+	marked          map[int64]bool // Logs selected for a bulk operation, by markKey
+	bulkList        list.Model
+	bulkAction      string
+	bulkValue       string
+	reviewList      list.Model
+	problemList     list.Model
+	contestList     list.Model
+	searchList      list.Model
+	searchInput     textinput.Model
+	quickAddInput   textinput.Model
+	contestSummary  string
+	contestName     string
+	selectedContest model.Contest
+	contestLogs     []model.Log
 	questionIDInput textinput.Model
 	timeInput       textinput.Model
-	notesInput      textinput.Model
+	dateInput       textinput.Model
+	notesInput      textarea.Model
+	progressBar     progress.Model
+	goalProgress    []db.GoalProgress
 	errorMsg        string
+	statusMsg       string
 	isEditing       bool
 	editingLogDate  time.Time
+	duplicateOf     model.Log // A log the one being saved may duplicate
+	allowDuplicate  bool
 }
 
 type clearErrorMsg struct{}
@@ -112,25 +174,33 @@ func NewForm() formModel {
 	const listPadding = 2
 
 	mainMenuItems := []list.Item{
-		menuItem("Platform"), menuItem("Topic"), menuItem("Difficulty"),
-		menuItem("Question ID"), menuItem("Time Spent"), menuItem("Notes"),
+		menuItem("Platform"), menuItem("Topic"), menuItem("Difficulty"), menuItem("Status"),
+		menuItem("Question ID"), menuItem("Time Spent"), menuItem("Date"), menuItem("Notes"), menuItem("Contest"),
 		menuItem("Submit & Add Another"),
+		menuItem("Quick Add"),
 		menuItem("View Logs"),
+		menuItem("Search"),
+		menuItem("Goals"),
+		menuItem("Due for Review"),
+		menuItem("Problems"),
+		menuItem("Upsolve Backlog"),
+		menuItem("Contests"),
 		menuItem("Quit"),
 	}
 	mainMenu := list.New(mainMenuItems, menuItemDelegate{}, defaultWidth, len(mainMenuItems)+listPadding)
 	mainMenu.SetShowTitle(false)
 
 	subListDelegate := menuItemDelegate{}
-	platformItems := []list.Item{menuItem("Codeforces"), menuItem("LeetCode"), menuItem("AtCoder"), menuItem("HackerRank"), menuItem("CSES")}
+	platformItems := make([]list.Item, len(model.Platforms))
+	for i, p := range model.Platforms {
+		platformItems[i] = menuItem(p)
+	}
 	platformList := list.New(platformItems, subListDelegate, defaultWidth, len(platformItems)+listPadding)
 	platformList.Title = "Choose a Platform"
 
-	topicItems := []list.Item{
-		menuItem("Ad-Hoc"), menuItem("Binary Search"), menuItem("Bit Manipulation"),
-		menuItem("Data Structures"), menuItem("DP"), menuItem("Game Theory"),
-		menuItem("Graphs"), menuItem("Greedy"), menuItem("Implementation"),
-		menuItem("Math"), menuItem("Strings"), menuItem("Two Pointers"),
+	topicItems := make([]list.Item, len(model.Topics))
+	for i, t := range model.Topics {
+		topicItems[i] = menuItem(t)
 	}
 	topicList := list.New(topicItems, subListDelegate, defaultWidth, len(topicItems)+listPadding)
 	topicList.Title = "Choose a Topic"
@@ -139,6 +209,13 @@ func NewForm() formModel {
 	difficultyList := list.New(difficultyItems, subListDelegate, defaultWidth, len(difficultyItems)+listPadding)
 	difficultyList.Title = "Choose a Difficulty"
 
+	statusItems := make([]list.Item, len(model.Statuses))
+	for i, status := range model.Statuses {
+		statusItems[i] = menuItem(status)
+	}
+	statusList := list.New(statusItems, subListDelegate, defaultWidth, len(statusItems)+listPadding)
+	statusList.Title = "Choose a Status (Attempted = WA/TLE, Upsolve = solve later)"
+
 	allLogs, err := db.GetAllLogs()
 	if err != nil {
 		allLogs = []model.Log{}
@@ -147,17 +224,24 @@ func NewForm() formModel {
 	for i, lg := range allLogs {
 		logItems[i] = logListItem(lg)
 	}
-	logDelegate := list.NewDefaultDelegate()
-	logDelegate.Styles.SelectedTitle = selectedItemStyle
-	logDelegate.Styles.SelectedDesc = descriptionStyle
-	logDelegate.Styles.NormalDesc = descriptionStyle
-	logDelegate.Styles.DimmedDesc = descriptionStyle
-	logsList := list.New(logItems, logDelegate, defaultWidth, 14)
+	marked := make(map[int64]bool)
+	logsList := list.New(logItems, markedDelegate{newLogDelegate(), marked}, defaultWidth, 14)
 	logsList.Title = "Saved Logs"
+	logsList.Filter = queryFilter(allLogs)
+	logsList.FilterInput.Placeholder = "e.g. topic:dp difficulty:hard date>=2026-01-01 time>60"
 	logsList.AdditionalShortHelpKeys = func() []key.Binding {
 		return []key.Binding{
 			key.NewBinding(key.WithKeys("ctrl+e"), key.WithHelp("ctrl+e", "edit")),
 			key.NewBinding(key.WithKeys("ctrl+d"), key.WithHelp("ctrl+d", "delete")),
+			key.NewBinding(key.WithKeys("u"), key.WithHelp("u", "undo")),
+			key.NewBinding(key.WithKeys(" "), key.WithHelp("space", "select")),
+			key.NewBinding(key.WithKeys("x"), key.WithHelp("x", "bulk actions")),
+		}
+	}
+	logsList.AdditionalFullHelpKeys = func() []key.Binding {
+		return []key.Binding{
+			key.NewBinding(key.WithKeys("ctrl+a"), key.WithHelp("ctrl+a", "select all")),
+			key.NewBinding(key.WithKeys("t"), key.WithHelp("t", "table view")),
 		}
 	}
 
@@ -171,25 +255,33 @@ func NewForm() formModel {
 	timeInput.CharLimit = 3
 	timeInput.Width = 20
 
-	notesInput := textinput.New()
-	notesInput.Placeholder = "e.g., Used two pointers"
-	notesInput.CharLimit = 100
-	notesInput.Width = 50
-
 	m := formModel{
 		currentView:     viewMain,
 		mainMenu:        mainMenu,
 		platforms:       platformList,
 		topics:          topicList,
 		difficulty:      difficultyList,
+		statuses:        statusList,
+		logEntry:        model.Log{Status: model.StatusAccepted},
 		logsList:        logsList,
+		logsTable:       newLogsTable(defaultWidth, 14),
+		tableLayout:     config.Get().LogLayout == "table",
+		marked:          marked,
+		reviewList:      newReviewList(nil, defaultWidth),
+		problemList:     newProblemList("Problems", nil, defaultWidth),
+		contestList:     newContestList("Contests", nil, defaultWidth, false),
+		searchList:      newSearchList(nil, nil, defaultWidth),
+		searchInput:     newSearchInput(),
+		quickAddInput:   newQuickAddInput(),
 		questionIDInput: questionIDInput,
 		timeInput:       timeInput,
-		notesInput:      notesInput,
+		dateInput:       newDateInput(),
+		notesInput:      newNotesInput(),
+		progressBar:     progress.New(progress.WithDefaultGradient(), progress.WithWidth(defaultWidth)),
 		isEditing:       false,
 	}
 
-	formLists := []*list.Model{&m.mainMenu, &m.platforms, &m.topics, &m.difficulty}
+	formLists := []*list.Model{&m.mainMenu, &m.platforms, &m.topics, &m.difficulty, &m.statuses}
 	for _, l := range formLists {
 		l.SetShowStatusBar(false)
 		l.SetShowFilter(false)
@@ -199,6 +291,9 @@ func NewForm() formModel {
 	m.logsList.SetShowStatusBar(false)
 	m.logsList.SetShowFilter(true)
 	m.logsList.SetShowPagination(true)
+	if m.tableLayout {
+		m = m.refreshLogsTable()
+	}
 
 	return m
 }
@@ -213,7 +308,18 @@ func (m formModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch msg := msg.(type) {
 	case clearErrorMsg:
 		m.errorMsg = ""
+		m.statusMsg = ""
 		return m, nil
+	case notesEditedMsg:
+		if msg.err != nil {
+			m.errorMsg = fmt.Sprintf("Editor Error: %v", msg.err)
+			return m, clearErrorAfter(5 * time.Second)
+		}
+		m.notesInput.SetValue(msg.notes)
+		m.logEntry.Notes = msg.notes
+		return m, nil
+	case solutionEditedMsg:
+		return m.saveEditedSolution(msg)
 	case tea.WindowSizeMsg:
 		w := msg.Width - 4
 		h := msg.Height - 8
@@ -221,10 +327,24 @@ func (m formModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.platforms.SetWidth(w)
 		m.topics.SetWidth(w)
 		m.difficulty.SetWidth(w)
+		m.statuses.SetWidth(w)
 		m.logsList.SetSize(w, h)
+		m.logsTable.SetWidth(w)
+		m.logsTable.SetHeight(h - 4)
+		if m.tableLayout {
+			m = m.refreshLogsTable()
+		}
+		m.reviewList.SetSize(w, h)
+		m.problemList.SetSize(w, h)
+		m.contestList.SetSize(w, h)
+		m.searchList.SetSize(w, h-2)
+		m.searchInput.Width = w
+		m.quickAddInput.Width = w
 		m.questionIDInput.Width = w
 		m.timeInput.Width = w
-		m.notesInput.Width = w
+		m.dateInput.Width = w
+		m.notesInput.SetWidth(w)
+		m.progressBar.Width = w
 		return m, nil
 	case tea.KeyMsg:
 		if msg.String() == "ctrl+c" || msg.String() == "ctrl+q" {
@@ -242,6 +362,8 @@ func (m formModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 					m.currentView = viewTopic
 				case "Difficulty":
 					m.currentView = viewDifficulty
+				case "Status":
+					m.currentView = viewStatus
 				case "Question ID":
 					m.currentView = viewQuestionID
 					m.questionIDInput.Focus()
@@ -252,55 +374,93 @@ func (m formModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 					m.timeInput.Focus()
 					m.questionIDInput.Blur()
 					m.notesInput.Blur()
+				case "Date":
+					m.currentView = viewDate
+					m.dateInput.Focus()
+					m.questionIDInput.Blur()
+					m.timeInput.Blur()
+					m.notesInput.Blur()
 				case "Notes":
 					m.currentView = viewNotes
 					m.notesInput.Focus()
 					m.questionIDInput.Blur()
 					m.timeInput.Blur()
+				case "Contest":
+					contests, err := db.GetAllContests()
+					if err != nil {
+						m.errorMsg = fmt.Sprintf("Contest Error: %v", err)
+						return m, clearErrorAfter(5 * time.Second)
+					}
+					m.contestList = newContestList("Choose a Contest", contests, m.logsList.Width(), true)
+					m.contestList.SetHeight(m.logsList.Height())
+					m.currentView = viewContestPicker
 				case "Submit & Add Another":
-					m.logEntry.QuestionID = m.questionIDInput.Value()
-					t, _ := strconv.Atoi(m.timeInput.Value())
-					m.logEntry.TimeSpent = t
-					m.logEntry.Notes = m.notesInput.Value()
-					if m.logEntry.Platform == "" || m.logEntry.Topic == "" || m.logEntry.Difficulty == "" || m.logEntry.QuestionID == "" {
-						m.errorMsg = "Error: Please fill out all fields before submitting."
-						return m, clearErrorAfter(2 * time.Second)
+					return m.submitLog()
+				case "Quick Add":
+					m.currentView = viewQuickAdd
+					return m, m.quickAddInput.Focus()
+				case "View Logs":
+					m.currentView = viewLogs
+				case "Search":
+					m.currentView = viewSearch
+					return m, m.searchInput.Focus()
+				case "Goals":
+					progress, err := db.GetGoalProgress()
+					if err != nil {
+						m.errorMsg = fmt.Sprintf("Goals Error: %v", err)
+						return m, clearErrorAfter(5 * time.Second)
 					}
-
-					// Set date before saving
-					if !m.isEditing {
-						m.logEntry.Date = time.Now()
-					} else {
-						m.logEntry.Date = m.editingLogDate
+					m.goalProgress = progress
+					m.currentView = viewGoals
+				case "Due for Review":
+					reviews, err := db.GetDueReviews(time.Now())
+					if err != nil {
+						m.errorMsg = fmt.Sprintf("Review Error: %v", err)
+						return m, clearErrorAfter(5 * time.Second)
 					}
-
-					// CORRECTED: Handle two return values from AddLogToCalendar
-					eventID, err := calendar.AddLogToCalendar(&m.logEntry)
+					m.reviewList = newReviewList(reviews, m.logsList.Width())
+					m.reviewList.SetHeight(m.logsList.Height())
+					m.currentView = viewReview
+				case "Problems":
+					stats, err := db.GetProblemStats()
 					if err != nil {
-						m.errorMsg = fmt.Sprintf("Calendar Error: %v", err)
+						m.errorMsg = fmt.Sprintf("Problems Error: %v", err)
 						return m, clearErrorAfter(5 * time.Second)
 					}
-					m.logEntry.CalendarEventID = eventID // Save the ID
-
-					if m.isEditing {
-						if err := db.UpdateLog(&m.logEntry); err != nil {
-							log.Fatal(err)
-						}
-					} else {
-						if err := db.SaveLog(&m.logEntry); err != nil {
-							log.Fatal(err)
-						}
+					m.problemList = newProblemList("Problems", stats, m.logsList.Width())
+					m.problemList.SetHeight(m.logsList.Height())
+					m.currentView = viewProblems
+				case "Contests":
+					contests, err := db.GetAllContests()
+					if err != nil {
+						m.errorMsg = fmt.Sprintf("Contest Error: %v", err)
+						return m, clearErrorAfter(5 * time.Second)
 					}
-					return NewForm(), tea.ClearScreen
-				case "View Logs":
-					m.currentView = viewLogs
+					summaries, err := db.GetContestSummaries()
+					if err != nil {
+						m.errorMsg = fmt.Sprintf("Contest Error: %v", err)
+						return m, clearErrorAfter(5 * time.Second)
+					}
+					m.contestSummary = contestSummaryView(summaries)
+					m.contestList = newContestList("Contests", contests, m.logsList.Width(), false)
+					m.contestList.SetHeight(m.logsList.Height() - len(summaries))
+					m.currentView = viewContests
+				case "Upsolve Backlog":
+					backlog, err := db.GetUpsolveBacklog()
+					if err != nil {
+						m.errorMsg = fmt.Sprintf("Upsolve Error: %v", err)
+						return m, clearErrorAfter(5 * time.Second)
+					}
+					m.problemList = newProblemList("Upsolve Backlog", backlog, m.logsList.Width())
+					m.problemList.SetHeight(m.logsList.Height())
+					m.currentView = viewProblems
 				case "Quit":
 					return m, tea.Quit
 				}
 				return m, nil
 			}
 
-		case viewPlatform, viewTopic, viewDifficulty:
+		case viewPlatform, viewTopic, viewDifficulty, viewStatus:
 			if msg.String() == "enter" {
 				switch m.currentView {
 				case viewPlatform:
@@ -309,6 +469,8 @@ func (m formModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 					m.logEntry.Topic = m.topics.SelectedItem().(menuItem).FilterValue()
 				case viewDifficulty:
 					m.logEntry.Difficulty = m.difficulty.SelectedItem().(menuItem).FilterValue()
+				case viewStatus:
+					m.logEntry.Status = m.statuses.SelectedItem().(menuItem).FilterValue()
 				}
 				m.mainMenu.CursorDown()
 				m.currentView = viewMain
@@ -323,64 +485,172 @@ func (m formModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			if m.logsList.FilterState() != list.Filtering {
 				switch msg.String() {
 				case "ctrl+e":
-					if len(m.logsList.Items()) > 0 {
-						selected := m.logsList.SelectedItem().(logListItem)
+					if selected, ok := m.selectedLogEntry(); ok {
 						m.isEditing = true
 						m.editingLogDate = selected.Date
-						m.logEntry = model.Log(selected)
+						m.logEntry = selected
+						m.logEntry.Status = m.logEntry.EffectiveStatus()
+						m.contestName = ""
+						if selected.ContestID != 0 {
+							if c, err := db.GetContest(selected.ContestID); err == nil {
+								m.contestName = c.Name
+							}
+						}
 						m.questionIDInput.SetValue(selected.QuestionID)
 						m.timeInput.SetValue(strconv.Itoa(selected.TimeSpent))
+						m.dateInput.SetValue(selected.Date.Format(dateInputLayout))
 						m.notesInput.SetValue(selected.Notes)
 						m.currentView = viewMain
 						return m, nil
 					}
 				case "ctrl+d":
-					if len(m.logsList.Items()) > 0 {
-						selected := m.logsList.SelectedItem().(logListItem)
-						m.selectedLog = model.Log(selected)
+					if selected, ok := m.selectedLogEntry(); ok {
+						m.selectedLog = selected
 						m.currentView = viewConfirmDelete
 						return m, nil
 					}
+				case "enter":
+					if selected, ok := m.selectedLogEntry(); ok {
+						m = m.openLogDetails(selected, viewLogs)
+					}
+					return m, nil
+				case "u":
+					return m.undo()
+				case " ":
+					return m.toggleMark(), nil
+				case "ctrl+a":
+					return m.markAll(), nil
+				case "x":
+					return m.openBulkActions(), nil
+				case "t":
+					return m.setLogLayout(!m.tableLayout), nil
+				}
+				if s := msg.String(); m.tableLayout && len(s) == 1 && s[0] >= '1' && int(s[0]-'1') < len(logColumns) {
+					return m.sortLogsTable(int(s[0] - '1')), nil
 				}
 			}
+			if msg.String() == "tab" {
+				m.currentView = viewMain
+				return m, nil
+			}
+
+		case viewLogDetails:
+			switch msg.String() {
+			case "o":
+				if err := utils.OpenBrowser(m.selectedURL); err != nil {
+					m.errorMsg = fmt.Sprintf("Error: %v", err)
+					return m, clearErrorAfter(3 * time.Second)
+				}
+				return m, nil
+			case "s":
+				if len(m.solutions) > 0 {
+					m.currentView = viewSolution
+				}
+				return m, nil
+			case "a":
+				return m, addSolution()
+			}
+			if msg.String() != "" {
+				m.currentView = m.detailsParent
+				return m, nil
+			}
+
+		case viewSolution:
+			return m.updateSolution(msg)
+
+		case viewSearch:
+			return m.updateSearch(msg)
+		case viewBulkActions, viewBulkValue, viewBulkConfirm:
+			return m.updateBulk(msg)
+		case viewQuickAdd:
+			return m.updateQuickAdd(msg)
+		case viewDuplicate:
+			return m.updateDuplicate(msg)
+
+		case viewGoals:
+			if msg.String() != "" {
+				m.currentView = viewMain
+				return m, nil
+			}
+
+		case viewReview:
+			if next, cmd, handled := m.updateReview(msg); handled {
+				return next, cmd
+			}
+
+		case viewProblems:
+			if m.problemList.FilterState() != list.Filtering && msg.String() == "tab" {
+				m.currentView = viewMain
+				return m, nil
+			}
+
+		case viewContestPicker, viewContests:
+			if m.contestList.FilterState() == list.Filtering {
+				break
+			}
 			switch msg.String() {
 			case "enter":
-				if len(m.logsList.Items()) > 0 {
-					selected := m.logsList.SelectedItem().(logListItem)
-					m.selectedLog = model.Log(selected)
-					m.currentView = viewLogDetails
+				selected, ok := m.contestList.SelectedItem().(contestListItem)
+				if !ok {
+					return m, nil
 				}
+				if m.currentView == viewContestPicker {
+					m.logEntry.ContestID = selected.ID
+					m.contestName = ""
+					if selected.ID != 0 {
+						m.contestName = selected.Name
+					}
+					m.mainMenu.CursorDown()
+					m.currentView = viewMain
+					return m, nil
+				}
+				logs, err := db.GetContestLogs(selected.ID)
+				if err != nil {
+					m.errorMsg = fmt.Sprintf("Contest Error: %v", err)
+					return m, clearErrorAfter(5 * time.Second)
+				}
+				m.selectedContest = model.Contest(selected)
+				m.contestLogs = logs
+				m.currentView = viewContestDetails
 				return m, nil
 			case "tab":
 				m.currentView = viewMain
 				return m, nil
 			}
 
-		case viewLogDetails:
+		case viewContestDetails:
 			if msg.String() != "" {
-				m.currentView = viewLogs
+				m.currentView = viewContests
 				return m, nil
 			}
 
 		case viewConfirmDelete:
 			switch msg.String() {
 			case "y", "Y":
-				// CORRECTED: Call DeleteCalendarEvent before deleting from local DB
-				if err := calendar.DeleteCalendarEvent(m.selectedLog.CalendarEventID); err != nil {
-					log.Printf("Could not delete calendar event (it may have been already deleted): %v", err)
-				}
-				if err := db.DeleteLog(m.selectedLog.Date); err != nil {
-					log.Fatal(err)
+				m.currentView = viewLogs
+				if err := core.DeleteLogs([]model.Log{m.selectedLog}); err != nil {
+					m.errorMsg = fmt.Sprintf("Delete Error: %v", err)
+					return m, clearErrorAfter(3 * time.Second)
 				}
-				freshModel := NewForm()
-				freshModel.currentView = viewLogs
-				return freshModel, tea.ClearScreen
+				return m.reloadLogs(), tea.ClearScreen
 			case "n", "N", "esc":
 				m.currentView = viewLogs
 				return m, nil
 			}
 
-		case viewQuestionID, viewTime, viewNotes:
+		case viewNotes:
+			switch msg.String() {
+			case "ctrl+o":
+				return m, editNotes(m.notesInput.Value())
+			case "tab", "esc":
+				m.logEntry.Notes = m.notesInput.Value()
+				m.notesInput.Blur()
+				m.mainMenu.CursorDown()
+				m.currentView = viewMain
+				return m, nil
+			}
+
+		case viewQuestionID, viewTime, viewDate:
 			if msg.String() == "enter" || msg.String() == "tab" {
 				switch m.currentView {
 				case viewQuestionID:
@@ -388,14 +658,17 @@ func (m formModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				case viewTime:
 					t, _ := strconv.Atoi(m.timeInput.Value())
 					m.logEntry.TimeSpent = t
-				case viewNotes:
-					m.logEntry.Notes = m.notesInput.Value()
+				case viewDate:
+					if _, err := m.formDate(); err != nil {
+						m.errorMsg = fmt.Sprintf("Error: %v", err)
+						return m, clearErrorAfter(3 * time.Second)
+					}
 				}
 				m.mainMenu.CursorDown()
 				m.currentView = viewMain
 				m.questionIDInput.Blur()
 				m.timeInput.Blur()
-				m.notesInput.Blur()
+				m.dateInput.Blur()
 				return m, nil
 			}
 		}
@@ -409,14 +682,28 @@ func (m formModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.topics, cmd = m.topics.Update(msg)
 	case viewDifficulty:
 		m.difficulty, cmd = m.difficulty.Update(msg)
+	case viewStatus:
+		m.statuses, cmd = m.statuses.Update(msg)
 	case viewQuestionID:
 		m.questionIDInput, cmd = m.questionIDInput.Update(msg)
 	case viewTime:
 		m.timeInput, cmd = m.timeInput.Update(msg)
+	case viewDate:
+		m.dateInput, cmd = m.dateInput.Update(msg)
 	case viewNotes:
 		m.notesInput, cmd = m.notesInput.Update(msg)
 	case viewLogs:
-		m.logsList, cmd = m.logsList.Update(msg)
+		if m.tableLayout {
+			m.logsTable, cmd = m.logsTable.Update(msg)
+		} else {
+			m.logsList, cmd = m.logsList.Update(msg)
+		}
+	case viewReview:
+		m.reviewList, cmd = m.reviewList.Update(msg)
+	case viewProblems:
+		m.problemList, cmd = m.problemList.Update(msg)
+	case viewContestPicker, viewContests:
+		m.contestList, cmd = m.contestList.Update(msg)
 	default: // viewMain
 		m.mainMenu, cmd = m.mainMenu.Update(msg)
 	}
@@ -424,37 +711,154 @@ func (m formModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	return m, cmd
 }
 
+// submitLog validates the form and saves the log, or the edits to it.
+func (m formModel) submitLog() (tea.Model, tea.Cmd) {
+	m.logEntry.QuestionID = m.questionIDInput.Value()
+	t, _ := strconv.Atoi(m.timeInput.Value())
+	m.logEntry.TimeSpent = t
+	m.logEntry.Notes = m.notesInput.Value()
+	if m.logEntry.Platform == "" || m.logEntry.Topic == "" || m.logEntry.Difficulty == "" || m.logEntry.QuestionID == "" {
+		m.errorMsg = "Error: Please fill out all fields before submitting."
+		return m, clearErrorAfter(2 * time.Second)
+	}
+	ref, err := platform.Resolve(m.logEntry.Platform, m.logEntry.QuestionID)
+	if err != nil {
+		m.errorMsg = fmt.Sprintf("Error: %v", err)
+		return m, clearErrorAfter(3 * time.Second)
+	}
+	m.logEntry.QuestionID = ref.ID
+
+	// Set date before saving
+	date, err := m.formDate()
+	if err != nil {
+		m.errorMsg = fmt.Sprintf("Error: %v", err)
+		return m, clearErrorAfter(3 * time.Second)
+	}
+	m.logEntry.Date = date
+
+	if !m.isEditing && !m.allowDuplicate {
+		if dup, found := core.FindDuplicate(m.logEntry); found {
+			m.duplicateOf = dup
+			m.currentView = viewDuplicate
+			return m, nil
+		}
+	}
+
+	// CORRECTED: Handle two return values from AddLogToCalendar
+	eventID, err := calendar.AddLogToCalendar(&m.logEntry)
+	if err != nil {
+		m.errorMsg = fmt.Sprintf("Calendar Error: %v", err)
+		return m, clearErrorAfter(5 * time.Second)
+	}
+	// Replace the old event rather than keeping a second one, now that the
+	// new one exists.
+	if m.isEditing && m.logEntry.CalendarEventID != "" {
+		if err := calendar.DeleteCalendarEvent(m.logEntry.CalendarEventID); err != nil {
+			log.Printf("Could not delete calendar event (it may have been already deleted): %v", err)
+		}
+	}
+	m.logEntry.CalendarEventID = eventID // Save the ID
+
+	if m.isEditing {
+		if err := db.UpdateLogAt(m.editingLogDate, &m.logEntry); err != nil {
+			log.Fatal(err)
+		}
+	} else {
+		if err := db.SaveLog(&m.logEntry); err != nil {
+			log.Fatal(err)
+		}
+	}
+	return NewForm(), tea.ClearScreen
+}
+
+// openLogDetails shows a log's details; leaving them goes back to parent.
+func (m formModel) openLogDetails(l model.Log, parent currentView) formModel {
+	m.selectedLog = l
+	n, total, err := db.AttemptNumber(l)
+	if err != nil {
+		log.Printf("could not count attempts: %v", err)
+	}
+	m.attemptNumber, m.attemptCount = n, total
+	m.selectedURL = db.ProblemURL(l)
+	m.selectedNotes = renderNotes(l.Notes, m.logsList.Width()-detailsStyle.GetHorizontalFrameSize())
+	solutions, err := db.GetSolutions(l.Date)
+	if err != nil {
+		log.Printf("could not load solutions: %v", err)
+	}
+	m.solutions, m.solutionIndex = solutions, 0
+	m.detailsParent = parent
+	m.currentView = viewLogDetails
+	return m
+}
+
 func (m formModel) View() string {
 	var b strings.Builder
 
 	switch m.currentView {
 	case viewLogs:
-		b.WriteString(m.logsList.View())
+		if m.tableLayout {
+			b.WriteString(m.logsList.Styles.Title.Render(m.logsList.Title) + "\n\n" + m.logsTable.View() + "\n" + descriptionStyle.Render(logsTableHelp))
+		} else {
+			b.WriteString(m.logsList.View())
+		}
 	case viewLogDetails:
 		details := fmt.Sprintf(
-			"Question ID: %s\nPlatform:    %s\nTopic:       %s\nDifficulty:  %s\nDate:        %s\nTime Spent:  %d mins\n\nNotes:\n%s",
-			m.selectedLog.QuestionID, m.selectedLog.Platform, m.selectedLog.Topic, m.selectedLog.Difficulty,
-			m.selectedLog.Date.Format("2006-01-02"), m.selectedLog.TimeSpent, m.selectedLog.Notes,
-		)
-		b.WriteString(detailsStyle.Render(details) + "\n\n(Press any key to return to list)")
+			"Question ID: %s\nPlatform:    %s\nTopic:       %s\nDifficulty:  %s\nStatus:      %s\nDate:        %s\nTime Spent:  %d mins\nAttempt:     %d of %d\nURL:         %s\n\nNotes:\n",
+			m.selectedLog.QuestionID, m.selectedLog.Platform, m.selectedLog.Topic, m.selectedLog.Difficulty, m.selectedLog.EffectiveStatus(),
+			m.selectedLog.Date.Format("2006-01-02"), m.selectedLog.TimeSpent, m.attemptNumber, m.attemptCount, m.selectedURL,
+		) + m.selectedNotes
+		details += fmt.Sprintf("\n\nSolutions:   %d", len(m.solutions))
+		help := []string{"a: add a solution in $EDITOR"}
+		if len(m.solutions) > 0 {
+			help = append([]string{"s: view solutions"}, help...)
+		}
+		if m.selectedURL != "" {
+			help = append([]string{"o: open the problem in your browser"}, help...)
+		}
+		b.WriteString(detailsStyle.Render(details) + "\n\n(" + strings.Join(append(help, "any other key: go back"), " • ") + ")")
+	case viewSolution:
+		b.WriteString(m.solutionView())
+	case viewSearch:
+		b.WriteString(m.searchView())
+	case viewBulkActions, viewBulkValue, viewBulkConfirm:
+		b.WriteString(m.bulkView())
+	case viewQuickAdd:
+		b.WriteString(m.quickAddView())
+	case viewDuplicate:
+		b.WriteString(m.duplicateView())
 
 	case viewConfirmDelete:
-		question := fmt.Sprintf("Are you sure you want to delete this log?\n\n%s\n%s",
+		question := fmt.Sprintf("Move this log to the trash?\n\n%s\n%s\n\nPress u in the logs view to undo.",
 			m.selectedLog.QuestionID,
 			m.selectedLog.Platform,
 		)
 		b.WriteString(detailsStyle.Render(question) + "\n\n(y/n)")
+	case viewGoals:
+		b.WriteString(m.goalsView())
+	case viewReview:
+		b.WriteString(m.reviewList.View() + "\n" + descriptionStyle.Render(reviewHelp))
+	case viewProblems:
+		b.WriteString(m.problemList.View())
+	case viewContests:
+		if m.contestSummary != "" {
+			b.WriteString(m.contestSummary + "\n\n")
+		}
+		b.WriteString(m.contestList.View())
+	case viewContestDetails:
+		b.WriteString(m.contestDetailsView())
 	default:
 		title := "--- Your New Log ---"
 		if m.isEditing {
 			title = fmt.Sprintf("--- Editing Log (%s) ---", m.logEntry.QuestionID)
 		}
 		summary := fmt.Sprintf(
-			"Platform: %s\nTopic: %s\nDifficulty: %s\nQuestion ID: %s\nTime: %d\nNotes: %s",
-			m.logEntry.Platform, m.logEntry.Topic, m.logEntry.Difficulty,
+			"Platform: %s\nTopic: %s\nDifficulty: %s\nStatus: %s\nQuestion ID: %s\nTime: %d\nDate: %s\nNotes: %s\nContest: %s",
+			m.logEntry.Platform, m.logEntry.Topic, m.logEntry.Difficulty, m.logEntry.Status,
 			m.questionIDInput.Value(),
 			m.logEntry.TimeSpent,
-			m.notesInput.Value(),
+			m.dateSummary(),
+			notesSummary(m.notesInput.Value()),
+			m.contestName,
 		)
 		var currentInputView string
 		switch m.currentView {
@@ -464,12 +868,18 @@ func (m formModel) View() string {
 			currentInputView = m.topics.View()
 		case viewDifficulty:
 			currentInputView = m.difficulty.View()
+		case viewStatus:
+			currentInputView = m.statuses.View()
+		case viewContestPicker:
+			currentInputView = m.contestList.View()
 		case viewQuestionID:
 			currentInputView = "Question ID:\n" + focusedStyle.Render(m.questionIDInput.View())
 		case viewTime:
 			currentInputView = "Time Spent (minutes):\n" + focusedStyle.Render(m.timeInput.View())
+		case viewDate:
+			currentInputView = "Date (empty for now):\n" + focusedStyle.Render(m.dateInput.View())
 		case viewNotes:
-			currentInputView = "Notes:\n" + focusedStyle.Render(m.notesInput.View())
+			currentInputView = "Notes:\n" + focusedStyle.Render(m.notesInput.View()) + "\n" + descriptionStyle.Render(notesHelp)
 		default: // viewMain
 			currentInputView = m.mainMenu.View()
 		}
@@ -479,6 +889,9 @@ func (m formModel) View() string {
 	if m.errorMsg != "" {
 		b.WriteString("\n\n" + errorStyle.Render(m.errorMsg))
 	}
+	if m.statusMsg != "" {
+		b.WriteString("\n\n" + descriptionStyle.Render(m.statusMsg))
+	}
 
 	return b.String()
 }
@@ -0,0 +1,226 @@
+package tui
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/Harschmann/Todo-/core"
+	"github.com/Harschmann/Todo-/model"
+	"github.com/charmbracelet/bubbles/list"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+const (
+	bulkDelete     = "Delete"
+	bulkTopic      = "Change Topic"
+	bulkDifficulty = "Change Difficulty"
+	bulkResync     = "Re-sync to Calendar"
+)
+
+const bulkHelp = "enter: choose • esc: back to logs"
+
+// markKey identifies a log in the set of logs marked for a bulk operation.
+func markKey(l model.Log) int64 { return l.Date.UnixNano() }
+
+// markedItem shows a log in the list with a check mark after its title.
+type markedItem struct{ logListItem }
+
+func (i markedItem) Title() string { return i.logListItem.Title() + " ✓" }
+
+// markedDelegate renders marked logs as markedItems. It shares the marked
+// set with the model, so marking a log needs no change to the list.
+type markedDelegate struct {
+	list.DefaultDelegate
+	marked map[int64]bool
+}
+
+func (d markedDelegate) Render(w io.Writer, m list.Model, index int, item list.Item) {
+	if l, ok := item.(logListItem); ok && d.marked[markKey(model.Log(l))] {
+		item = markedItem{l}
+	}
+	d.DefaultDelegate.Render(w, m, index, item)
+}
+
+func newBulkList(title string, items []list.Item, width int) list.Model {
+	l := list.New(items, menuItemDelegate{}, width, len(items)+2)
+	l.Title = title
+	l.SetShowStatusBar(false)
+	l.SetShowFilter(false)
+	l.SetShowPagination(false)
+	l.SetShowHelp(false)
+	l.SetFilteringEnabled(false)
+	return l
+}
+
+// toggleMark marks or unmarks the log under the cursor and moves to the next one.
+func (m formModel) toggleMark() formModel {
+	selected, ok := m.selectedLogEntry()
+	if !ok {
+		return m
+	}
+	if k := markKey(selected); m.marked[k] {
+		delete(m.marked, k)
+	} else {
+		m.marked[k] = true
+	}
+	if m.tableLayout {
+		m = m.refreshLogsTable()
+		m.logsTable.MoveDown(1)
+	} else {
+		m.logsList.CursorDown()
+	}
+	return m
+}
+
+// markAll marks every log currently shown, or clears the marks if they all
+// already are.
+func (m formModel) markAll() formModel {
+	visible := m.logsList.VisibleItems()
+	all := true
+	for _, item := range visible {
+		if !m.marked[markKey(model.Log(item.(logListItem)))] {
+			all = false
+			break
+		}
+	}
+	clear(m.marked)
+	if !all {
+		for _, item := range visible {
+			m.marked[markKey(model.Log(item.(logListItem)))] = true
+		}
+	}
+	if m.tableLayout {
+		m = m.refreshLogsTable()
+	}
+	return m
+}
+
+// markedLogs returns the marked logs, oldest first.
+func (m formModel) markedLogs() []model.Log {
+	var logs []model.Log
+	for _, item := range m.logsList.Items() {
+		if l := model.Log(item.(logListItem)); m.marked[markKey(l)] {
+			logs = append(logs, l)
+		}
+	}
+	return logs
+}
+
+// openBulkActions asks what to do with the marked logs.
+func (m formModel) openBulkActions() formModel {
+	if len(m.marked) == 0 {
+		return m
+	}
+	items := []list.Item{menuItem(bulkDelete), menuItem(bulkTopic), menuItem(bulkDifficulty), menuItem(bulkResync)}
+	m.bulkList = newBulkList(fmt.Sprintf("%d logs selected", len(m.marked)), items, m.logsList.Width())
+	m.currentView = viewBulkActions
+	return m
+}
+
+func (m formModel) updateBulk(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch m.currentView {
+	case viewBulkActions, viewBulkValue:
+		switch msg.String() {
+		case "esc", "tab":
+			m.currentView = viewLogs
+			return m, nil
+		case "enter":
+			choice := string(m.bulkList.SelectedItem().(menuItem))
+			if m.currentView == viewBulkValue {
+				m.bulkValue = choice
+				m.currentView = viewBulkConfirm
+				return m, nil
+			}
+			m.bulkAction = choice
+			switch choice {
+			case bulkTopic:
+				m.bulkList = newBulkList("New topic", m.topics.Items(), m.logsList.Width())
+				m.currentView = viewBulkValue
+			case bulkDifficulty:
+				m.bulkList = newBulkList("New difficulty", m.difficulty.Items(), m.logsList.Width())
+				m.currentView = viewBulkValue
+			default:
+				m.currentView = viewBulkConfirm
+			}
+			return m, nil
+		}
+		var cmd tea.Cmd
+		m.bulkList, cmd = m.bulkList.Update(msg)
+		return m, cmd
+
+	case viewBulkConfirm:
+		switch msg.String() {
+		case "y", "Y":
+			return m.runBulk()
+		case "n", "N", "esc":
+			m.currentView = viewLogs
+		}
+	}
+	return m, nil
+}
+
+// runBulk applies the chosen action to every marked log in one transaction.
+func (m formModel) runBulk() (tea.Model, tea.Cmd) {
+	logs := m.markedLogs()
+	var err error
+	var status string
+	switch m.bulkAction {
+	case bulkDelete:
+		err = core.DeleteLogs(logs)
+		status = fmt.Sprintf("Moved %d logs to the trash (u to undo)", len(logs))
+	case bulkTopic, bulkDifficulty:
+		for i := range logs {
+			if m.bulkAction == bulkTopic {
+				logs[i].Topic = m.bulkValue
+			} else {
+				logs[i].Difficulty = m.bulkValue
+			}
+		}
+		err = core.UpdateLogs(logs)
+		status = fmt.Sprintf("Updated %d logs (u to undo)", len(logs))
+	case bulkResync:
+		err = core.UpdateLogs(logs)
+		// A re-sync changes no log, so there is nothing to undo.
+		status = fmt.Sprintf("Re-synced %d logs to the calendar", len(logs))
+	}
+	if err != nil {
+		m.errorMsg = fmt.Sprintf("Bulk Error: %v", err)
+		m.currentView = viewLogs
+		return m, clearErrorAfter(5 * time.Second)
+	}
+	m = m.reloadLogs()
+	m.currentView = viewLogs
+	m.statusMsg = status
+	return m, clearErrorAfter(3 * time.Second)
+}
+
+func (m formModel) bulkView() string {
+	if m.currentView != viewBulkConfirm {
+		return m.bulkList.View() + "\n" + descriptionStyle.Render(bulkHelp)
+	}
+	logs := m.markedLogs()
+	var question string
+	switch m.bulkAction {
+	case bulkDelete:
+		question = fmt.Sprintf("Move these %d logs to the trash?", len(logs))
+	case bulkTopic:
+		question = fmt.Sprintf("Change the topic of these %d logs to %s?", len(logs), m.bulkValue)
+	case bulkDifficulty:
+		question = fmt.Sprintf("Change the difficulty of these %d logs to %s?", len(logs), m.bulkValue)
+	case bulkResync:
+		question = fmt.Sprintf("Recreate the calendar events of these %d logs?", len(logs))
+	}
+	const shown = 8
+	var b strings.Builder
+	b.WriteString(question + "\n")
+	for i, l := range logs {
+		if i == shown {
+			fmt.Fprintf(&b, "\n... and %d more", len(logs)-shown)
+			break
+		}
+		fmt.Fprintf(&b, "\n%s  %s (%s)", l.Date.Format("2006-01-02"), l.QuestionID, l.Platform)
+	}
+	return detailsStyle.Render(b.String()) + "\n\n(y/n)"
+}
@@ -0,0 +1,96 @@
+package tui
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/Harschmann/Todo-/db"
+	"github.com/Harschmann/Todo-/model"
+	"github.com/charmbracelet/bubbles/list"
+)
+
+type contestListItem model.Contest
+
+func (c contestListItem) FilterValue() string { return c.Name + " " + c.Platform }
+func (c contestListItem) Title() string       { return c.Name }
+func (c contestListItem) Description() string {
+	if c.ID == 0 {
+		return "Not part of a contest"
+	}
+	return fmt.Sprintf("%s | %s | rank %d | %+d", c.Platform, c.Date.Format("2006-01-02"), c.Rank, c.RatingDelta)
+}
+
+// newContestList lists contests, optionally with a leading "No contest" entry
+// for the form's contest picker.
+func newContestList(title string, contests []model.Contest, width int, withNone bool) list.Model {
+	var items []list.Item
+	if withNone {
+		items = append(items, contestListItem{Name: "No contest"})
+	}
+	for _, c := range contests {
+		items = append(items, contestListItem(c))
+	}
+	l := list.New(items, newLogDelegate(), width, 14)
+	l.Title = title
+	l.SetShowStatusBar(false)
+	return l
+}
+
+var sparkBlocks = []rune("▁▂▃▄▅▆▇█")
+
+// sparkline draws the known part of the rating history as a row of block
+// characters.
+func sparkline(history []db.RatingPoint) string {
+	for len(history) > 0 && !history[0].Known {
+		history = history[1:]
+	}
+	if len(history) == 0 {
+		return ""
+	}
+	lo, hi := history[0].Rating, history[0].Rating
+	for _, p := range history {
+		lo = min(lo, p.Rating)
+		hi = max(hi, p.Rating)
+	}
+	var b strings.Builder
+	for _, p := range history {
+		i := 0
+		if hi > lo {
+			i = (p.Rating - lo) * (len(sparkBlocks) - 1) / (hi - lo)
+		}
+		b.WriteRune(sparkBlocks[i])
+	}
+	return b.String()
+}
+
+func contestSummaryView(summaries []db.ContestSummary) string {
+	var lines []string
+	for _, s := range summaries {
+		rating := "?"
+		if last := s.History[len(s.History)-1]; last.Known {
+			rating = strconv.Itoa(last.Rating)
+		}
+		lines = append(lines, fmt.Sprintf("%s: %d contests · rating %s (%+d) · best rank %d · %d solved  %s",
+			s.Platform, s.Contests, rating, s.TotalDelta, s.BestRank, s.Solved, sparkline(s.History)))
+	}
+	return summaryStyle.Render(strings.Join(lines, "\n"))
+}
+
+func (m formModel) contestDetailsView() string {
+	c := m.selectedContest
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s\nPlatform:  %s\nDate:      %s\nDuration:  %d mins\nRank:      %d\nRating:    %+d",
+		c.Name, c.Platform, c.Date.Format("2006-01-02 15:04"), c.Duration, c.Rank, c.RatingDelta)
+	if c.NewRating != 0 {
+		fmt.Fprintf(&b, " (now %d)", c.NewRating)
+	}
+	b.WriteString("\n\nProblems:")
+	if len(m.contestLogs) == 0 {
+		b.WriteString("\n  none logged")
+	}
+	for _, l := range m.contestLogs {
+		fmt.Fprintf(&b, "\n  %-12s %-10s %-14s %d mins", l.QuestionID, l.EffectiveStatus(), l.Topic, l.TimeSpent)
+	}
+	return detailsStyle.Render(b.String()) + "\n\n(Press any key to return to contests)"
+}
@@ -0,0 +1,43 @@
+package tui
+
+import (
+	"strings"
+	"time"
+
+	"github.com/Harschmann/Todo-/utils"
+	"github.com/charmbracelet/bubbles/textinput"
+)
+
+// dateInputLayout is how an edited log's date is filled into the date field.
+const dateInputLayout = "2006-01-02 15:04"
+
+func newDateInput() textinput.Model {
+	input := textinput.New()
+	input.Placeholder = "now, yesterday, yesterday 21:30, -2d or 2026-01-31 20:35"
+	input.CharLimit = 40
+	input.Width = 40
+	return input
+}
+
+// formDate returns the date to save the log under. An empty field means now
+// for a new log; an edited log whose date field was left alone or cleared
+// keeps its exact timestamp.
+func (m formModel) formDate() (time.Time, error) {
+	value := strings.TrimSpace(m.dateInput.Value())
+	if m.isEditing && (value == "" || value == m.editingLogDate.Format(dateInputLayout)) {
+		return m.editingLogDate, nil
+	}
+	return utils.ParseLogDate(value)
+}
+
+// dateSummary describes the date field in the form summary.
+func (m formModel) dateSummary() string {
+	if strings.TrimSpace(m.dateInput.Value()) == "" && !m.isEditing {
+		return "now"
+	}
+	t, err := m.formDate()
+	if err != nil {
+		return m.dateInput.Value() + " (invalid)"
+	}
+	return t.Format(dateInputLayout)
+}
@@ -0,0 +1,52 @@
+package tui
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/Harschmann/Todo-/core"
+	"github.com/Harschmann/Todo-/model"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+const duplicateHelp = "m: merge into it • r: add as a re-attempt • c/esc: cancel"
+
+func (m formModel) updateDuplicate(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "m", "M":
+		merged := core.Merge(m.duplicateOf, m.logEntry)
+		if err := core.UpdateLogs([]model.Log{merged}); err != nil {
+			m.errorMsg = fmt.Sprintf("Error: %v", err)
+			return m, clearErrorAfter(3 * time.Second)
+		}
+		next := NewForm()
+		next.statusMsg = fmt.Sprintf("Merged into the log from %s. Press u in the logs view to undo.", merged.Date.Format(dateInputLayout))
+		return next, tea.Batch(tea.ClearScreen, clearErrorAfter(3*time.Second))
+	case "r", "R":
+		m.currentView = viewMain
+		m.allowDuplicate = true
+		next, cmd := m.submitLog()
+		if form, ok := next.(formModel); ok {
+			// Only this submit skips the check, in case it failed and the
+			// log is changed before the next one.
+			form.allowDuplicate = false
+			next = form
+		}
+		return next, cmd
+	case "c", "C", "esc":
+		m.currentView = viewMain
+		return m, nil
+	}
+	return m, nil
+}
+
+// duplicateView compares the log being saved with the one it may duplicate.
+func (m formModel) duplicateView() string {
+	existing, adding := m.duplicateOf, m.logEntry
+	text := fmt.Sprintf("%s %s was already logged on %s.\n\nExisting: %s | %s | %s | %d mins\nThis one: %s | %s | %s | %d mins\n\nMerge them into one log, or keep both as separate attempts?",
+		adding.Platform, adding.QuestionID, existing.Date.Format(dateInputLayout),
+		existing.Topic, existing.Difficulty, existing.EffectiveStatus(), existing.TimeSpent,
+		adding.Topic, adding.Difficulty, adding.EffectiveStatus(), adding.TimeSpent,
+	)
+	return detailsStyle.Render(text) + "\n\n" + descriptionStyle.Render(duplicateHelp)
+}
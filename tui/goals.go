@@ -0,0 +1,34 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Harschmann/Todo-/db"
+)
+
+var goalMetStyle = selectedItemStyle.PaddingLeft(0)
+
+func (m formModel) goalsView() string {
+	var b strings.Builder
+	b.WriteString("--- Goals ---\n\n")
+	if len(m.goalProgress) == 0 {
+		b.WriteString(descriptionStyle.Render("No goals yet. Add one from the command line:\n  todoplusplus goal add -target 3 -period day"))
+		b.WriteString("\n\n(Press any key to return to menu)")
+		return b.String()
+	}
+	for _, p := range m.goalProgress {
+		b.WriteString(goalLine(p) + "\n")
+		b.WriteString(m.progressBar.ViewAs(p.Percent()) + "\n\n")
+	}
+	b.WriteString("(Press any key to return to menu)")
+	return b.String()
+}
+
+func goalLine(p db.GoalProgress) string {
+	line := fmt.Sprintf("%s: %d/%d", p.Goal, p.Current, p.Goal.Target)
+	if p.Met() {
+		return goalMetStyle.Render(line + " ✓")
+	}
+	return line + descriptionStyle.Render(fmt.Sprintf("  (%d to go)", p.Remaining()))
+}
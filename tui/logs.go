@@ -0,0 +1,254 @@
+package tui
+
+import (
+	"fmt"
+	"log"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Harschmann/Todo-/config"
+	"github.com/Harschmann/Todo-/core"
+	"github.com/Harschmann/Todo-/db"
+	"github.com/Harschmann/Todo-/model"
+	"github.com/Harschmann/Todo-/query"
+	"github.com/charmbracelet/bubbles/list"
+	"github.com/charmbracelet/bubbles/table"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// queryFilter filters the logs list with the query language, e.g.
+// "topic:dp time>60". logs must be in the same order as the list items.
+// Input that is not a valid query yet, such as a half-typed "date>=",
+// falls back to fuzzy matching.
+func queryFilter(logs []model.Log) list.FilterFunc {
+	return func(term string, targets []string) []list.Rank {
+		q, err := query.Parse(term)
+		if err != nil || len(targets) != len(logs) {
+			return list.DefaultFilter(term, targets)
+		}
+		var ranks []list.Rank
+		for i, l := range logs {
+			if q.Match(l) {
+				ranks = append(ranks, list.Rank{Index: i})
+			}
+		}
+		return ranks
+	}
+}
+
+// logColumn is a column of the logs table.
+type logColumn struct {
+	key   string // Saved as config.LogSort
+	title string
+	width int // 0 takes the remaining width
+	value func(model.Log) string
+	less  func(a, b model.Log) bool
+}
+
+func byText(get func(model.Log) string) func(a, b model.Log) bool {
+	return func(a, b model.Log) bool { return strings.ToLower(get(a)) < strings.ToLower(get(b)) }
+}
+
+var logColumns = []logColumn{
+	{key: "date", title: "Date", width: 16,
+		value: func(l model.Log) string { return l.Date.Format("2006-01-02 15:04") },
+		less:  func(a, b model.Log) bool { return a.Date.Before(b.Date) }},
+	{key: "platform", title: "Platform", width: 12,
+		value: func(l model.Log) string { return l.Platform },
+		less:  byText(func(l model.Log) string { return l.Platform })},
+	{key: "id", title: "ID",
+		value: func(l model.Log) string { return l.QuestionID },
+		less:  byText(func(l model.Log) string { return l.QuestionID })},
+	{key: "topic", title: "Topic", width: 16,
+		value: func(l model.Log) string { return l.Topic },
+		less:  byText(func(l model.Log) string { return l.Topic })},
+	{key: "difficulty", title: "Difficulty", width: 14,
+		value: func(l model.Log) string { return l.Difficulty },
+		less:  lessDifficulty},
+	{key: "status", title: "Status", width: 10,
+		value: func(l model.Log) string { return l.EffectiveStatus() },
+		less:  byText(func(l model.Log) string { return l.EffectiveStatus() })},
+	{key: "time", title: "Time", width: 8,
+		value: func(l model.Log) string { return strconv.Itoa(l.TimeSpent) },
+		less:  func(a, b model.Log) bool { return a.TimeSpent < b.TimeSpent }},
+}
+
+var difficultyRanks = map[string]int{"easy": 1, "medium": 2, "hard": 3}
+
+// lessDifficulty orders Easy < Medium < Hard, then numeric ratings such as
+// 800 < 1600, then anything else alphabetically.
+func lessDifficulty(a, b model.Log) bool {
+	rank := func(d string) (int, int) {
+		if r, ok := difficultyRanks[strings.ToLower(d)]; ok {
+			return 0, r
+		}
+		if n, err := strconv.Atoi(strings.Fields(d + " x")[0]); err == nil {
+			return 1, n
+		}
+		return 2, 0
+	}
+	ga, ra := rank(a.Difficulty)
+	gb, rb := rank(b.Difficulty)
+	if ga != gb {
+		return ga < gb
+	}
+	if ga == 2 || ra == rb {
+		return strings.ToLower(a.Difficulty) < strings.ToLower(b.Difficulty)
+	}
+	return ra < rb
+}
+
+func sortColumn(key string) int {
+	for i, c := range logColumns {
+		if c.key == key {
+			return i
+		}
+	}
+	return 0
+}
+
+const logsTableHelp = "t: list view • 1-7: sort by column (again to reverse) • enter: details • ctrl+e: edit • ctrl+d: delete • u: undo\n" +
+	"space: select • ctrl+a: select all • x: bulk actions • tab: menu"
+
+func newLogsTable(width, height int) table.Model {
+	styles := table.DefaultStyles()
+	styles.Header = styles.Header.BorderStyle(lipgloss.NormalBorder()).BorderBottom(true).Bold(true)
+	styles.Selected = styles.Selected.Foreground(lipgloss.Color("170")).Bold(false)
+	t := table.New(table.WithFocused(true), table.WithStyles(styles), table.WithWidth(width), table.WithHeight(height))
+	return t
+}
+
+// refreshLogsTable fills the table with the logs the list currently shows,
+// so a filter set in the list layout carries over, sorted by the saved column.
+func (m formModel) refreshLogsTable() formModel {
+	cfg := config.Get()
+	col := sortColumn(cfg.LogSort)
+
+	logs := make([]model.Log, 0, len(m.logsList.VisibleItems()))
+	for _, item := range m.logsList.VisibleItems() {
+		logs = append(logs, model.Log(item.(logListItem)))
+	}
+	less := logColumns[col].less
+	sort.SliceStable(logs, func(i, j int) bool {
+		if cfg.LogSortDesc {
+			return less(logs[j], logs[i])
+		}
+		return less(logs[i], logs[j])
+	})
+
+	// Each cell is padded by one space on either side. The first column
+	// shows which logs are selected for a bulk operation.
+	flexible := m.logsTable.Width() - 2*(len(logColumns)+1) - 1
+	for _, c := range logColumns {
+		flexible -= c.width
+	}
+	columns := make([]table.Column, 1, len(logColumns)+1)
+	columns[0] = table.Column{Title: " ", Width: 1}
+	for i, c := range logColumns {
+		title := fmt.Sprintf("%d %s", i+1, c.title)
+		if i == col {
+			title += map[bool]string{false: " ▲", true: " ▼"}[cfg.LogSortDesc]
+		}
+		width := c.width
+		if width == 0 {
+			width = max(flexible, 10)
+		}
+		columns = append(columns, table.Column{Title: title, Width: width})
+	}
+	rows := make([]table.Row, len(logs))
+	for i, l := range logs {
+		row := make(table.Row, 1, len(logColumns)+1)
+		if m.marked[markKey(l)] {
+			row[0] = "✓"
+		}
+		for _, c := range logColumns {
+			row = append(row, c.value(l))
+		}
+		rows[i] = row
+	}
+
+	m.tableLogs = logs
+	m.logsTable.SetRows(nil)
+	m.logsTable.SetColumns(columns)
+	m.logsTable.SetRows(rows)
+	if m.logsTable.Cursor() >= len(rows) {
+		m.logsTable.SetCursor(max(len(rows)-1, 0))
+	}
+	return m
+}
+
+// selectedLogEntry returns the log under the cursor in whichever layout is shown.
+func (m formModel) selectedLogEntry() (model.Log, bool) {
+	if m.tableLayout {
+		if c := m.logsTable.Cursor(); c >= 0 && c < len(m.tableLogs) {
+			return m.tableLogs[c], true
+		}
+		return model.Log{}, false
+	}
+	selected, ok := m.logsList.SelectedItem().(logListItem)
+	return model.Log(selected), ok
+}
+
+// setLogLayout switches between the list and table layouts and remembers the choice.
+func (m formModel) setLogLayout(tableLayout bool) formModel {
+	m.tableLayout = tableLayout
+	cfg := config.Get()
+	cfg.LogLayout = map[bool]string{false: "list", true: "table"}[tableLayout]
+	if err := config.SaveState(cfg); err != nil {
+		log.Printf("could not save log layout: %v", err)
+	}
+	if tableLayout {
+		m = m.refreshLogsTable()
+	}
+	return m
+}
+
+// sortLogsTable sorts by column i, reversing the order if it already is the
+// sort column, and remembers the choice.
+func (m formModel) sortLogsTable(i int) formModel {
+	cfg := config.Get()
+	if cfg.LogSort == logColumns[i].key {
+		cfg.LogSortDesc = !cfg.LogSortDesc
+	} else {
+		cfg.LogSort, cfg.LogSortDesc = logColumns[i].key, false
+	}
+	if err := config.SaveState(cfg); err != nil {
+		log.Printf("could not save log sort: %v", err)
+	}
+	return m.refreshLogsTable()
+}
+
+// undo reverts the most recent delete or edit and reloads the logs.
+func (m formModel) undo() (tea.Model, tea.Cmd) {
+	status, err := core.Undo()
+	if err != nil {
+		m.errorMsg = fmt.Sprintf("Undo Error: %v", err)
+		return m, clearErrorAfter(3 * time.Second)
+	}
+	m = m.reloadLogs()
+	m.statusMsg = status
+	return m, clearErrorAfter(3 * time.Second)
+}
+
+// reloadLogs refreshes the logs view from the database, clearing any marks.
+func (m formModel) reloadLogs() formModel {
+	allLogs, err := db.GetAllLogs()
+	if err != nil {
+		log.Printf("could not reload logs: %v", err)
+		return m
+	}
+	items := make([]list.Item, len(allLogs))
+	for i, l := range allLogs {
+		items[i] = logListItem(l)
+	}
+	m.logsList.Filter = queryFilter(allLogs)
+	m.logsList.SetItems(items)
+	clear(m.marked)
+	if m.tableLayout {
+		m = m.refreshLogsTable()
+	}
+	return m
+}
@@ -0,0 +1,131 @@
+package tui
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textarea"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/glamour"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// notesCharLimit leaves room for a full approach write-up.
+const notesCharLimit = 10000
+
+const notesHelp = "tab/esc: done • ctrl+o: open in $EDITOR • enter: new line"
+
+// notesEditedMsg carries the notes back from an external editor.
+type notesEditedMsg struct {
+	notes string
+	err   error
+}
+
+func newNotesInput() textarea.Model {
+	notesInput := textarea.New()
+	notesInput.Placeholder = "e.g., Used two pointers. Markdown is supported."
+	notesInput.CharLimit = notesCharLimit
+	notesInput.ShowLineNumbers = false
+	notesInput.SetWidth(50)
+	notesInput.SetHeight(10)
+	return notesInput
+}
+
+// editorCommand picks the user's editor from $VISUAL or $EDITOR.
+func editorCommand(path string) *exec.Cmd {
+	editor := os.Getenv("VISUAL")
+	if editor == "" {
+		editor = os.Getenv("EDITOR")
+	}
+	if editor == "" {
+		editor = "vi"
+		if runtime.GOOS == "windows" {
+			editor = "notepad"
+		}
+	}
+	// Editors are often configured with arguments, e.g. "code --wait".
+	args := strings.Fields(editor)
+	return exec.Command(args[0], append(args[1:], path)...)
+}
+
+// openEditor writes text to a temp file named after pattern, suspends the TUI
+// while the editor runs, and hands the edited text to done once it exits.
+func openEditor(text, pattern string, done func(string, error) tea.Msg) tea.Cmd {
+	f, err := os.CreateTemp("", pattern)
+	if err != nil {
+		return func() tea.Msg { return done("", err) }
+	}
+	path := f.Name()
+	_, err = f.WriteString(text)
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		os.Remove(path)
+		return func() tea.Msg { return done("", err) }
+	}
+
+	return tea.ExecProcess(editorCommand(path), func(err error) tea.Msg {
+		defer os.Remove(path)
+		if err != nil {
+			return done("", fmt.Errorf("editor failed: %w", err))
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return done("", err)
+		}
+		return done(strings.TrimRight(string(data), "\n"), nil)
+	})
+}
+
+func editNotes(notes string) tea.Cmd {
+	return openEditor(notes, "todoplusplus-notes-*.md", func(text string, err error) tea.Msg {
+		return notesEditedMsg{notes: text, err: err}
+	})
+}
+
+// notesSummary shortens multi-line notes to one line for the form summary.
+func notesSummary(notes string) string {
+	lines := strings.Split(strings.TrimSpace(notes), "\n")
+	if len(lines) <= 1 {
+		return lines[0]
+	}
+	return fmt.Sprintf("%s (+%d lines)", lines[0], len(lines)-1)
+}
+
+// renderNotes renders Markdown notes for the details view, falling back to
+// the raw text if rendering fails.
+func renderNotes(notes string, width int) string {
+	if strings.TrimSpace(notes) == "" {
+		return descriptionStyle.Render("No notes.")
+	}
+	return renderMarkdown(notes, width)
+}
+
+// markdownStyle is the glamour style for the terminal's background. It is
+// detected once by DetectBackground, since querying the terminal while the
+// program is reading its input would swallow the reply.
+var markdownStyle = "dark"
+
+// DetectBackground asks the terminal whether its background is dark. Call it
+// before starting the program.
+func DetectBackground() {
+	if !lipgloss.HasDarkBackground() {
+		markdownStyle = "light"
+	}
+}
+
+func renderMarkdown(markdown string, width int) string {
+	renderer, err := glamour.NewTermRenderer(glamour.WithStandardStyle(markdownStyle), glamour.WithWordWrap(width))
+	if err != nil {
+		return markdown
+	}
+	out, err := renderer.Render(markdown)
+	if err != nil {
+		return markdown
+	}
+	return strings.Trim(out, "\n")
+}
@@ -0,0 +1,34 @@
+package tui
+
+import (
+	"fmt"
+
+	"github.com/Harschmann/Todo-/db"
+	"github.com/charmbracelet/bubbles/list"
+)
+
+type problemListItem db.ProblemStats
+
+func (p problemListItem) FilterValue() string {
+	return fmt.Sprintf("%s %s %s", p.Problem.ProblemID, p.Problem.Platform, p.Problem.Title)
+}
+func (p problemListItem) Title() string {
+	if p.Problem.Title != "" && p.Problem.Title != p.Problem.ProblemID {
+		return fmt.Sprintf("%s - %s", p.Problem.ProblemID, p.Problem.Title)
+	}
+	return p.Problem.ProblemID
+}
+func (p problemListItem) Description() string {
+	return fmt.Sprintf("%s | %d attempts (%s) | %d mins total", p.Problem.Platform, len(p.Attempts), db.ProblemStats(p).Outcome(), p.TotalTime)
+}
+
+func newProblemList(title string, stats []db.ProblemStats, width int) list.Model {
+	items := make([]list.Item, len(stats))
+	for i, s := range stats {
+		items[i] = problemListItem(s)
+	}
+	l := list.New(items, newLogDelegate(), width, 14)
+	l.Title = title
+	l.SetShowStatusBar(false)
+	return l
+}
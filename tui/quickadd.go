@@ -0,0 +1,90 @@
+package tui
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/Harschmann/Todo-/model"
+	"github.com/Harschmann/Todo-/quickadd"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+const quickAddHelp = "enter: save • tab: fill the form to review first • esc: back\n" +
+	"platform, ID, topic, difficulty, then optionally a status (wa, upsolve, ...), time (45m, 1h30m), @date (@yesterday, @-2d) and \"notes\""
+
+func newQuickAddInput() textinput.Model {
+	input := textinput.New()
+	input.Placeholder = `cf 1337A dp hard 45m "used prefix sums"`
+	input.CharLimit = 500
+	input.Width = 40
+	return input
+}
+
+// fillForm puts a parsed log into the form fields as a new log.
+func (m formModel) fillForm(l model.Log) formModel {
+	m.isEditing = false
+	m.logEntry = l
+	m.contestName = ""
+	m.questionIDInput.SetValue(l.QuestionID)
+	m.timeInput.SetValue(strconv.Itoa(l.TimeSpent))
+	m.notesInput.SetValue(l.Notes)
+	m.dateInput.SetValue("")
+	if !l.Date.IsZero() {
+		m.dateInput.SetValue(l.Date.Format(dateInputLayout))
+	}
+	return m
+}
+
+func (m formModel) updateQuickAdd(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "esc":
+		m.quickAddInput.Blur()
+		m.currentView = viewMain
+		return m, nil
+	case "enter", "tab":
+		l, err := quickadd.Parse(m.quickAddInput.Value())
+		if err != nil {
+			m.errorMsg = fmt.Sprintf("Error: %v", err)
+			return m, clearErrorAfter(3 * time.Second)
+		}
+		m.quickAddInput.Blur()
+		m.quickAddInput.SetValue("")
+		m = m.fillForm(l)
+		if msg.String() == "tab" {
+			for i, item := range m.mainMenu.Items() {
+				if item == menuItem("Submit & Add Another") {
+					m.mainMenu.Select(i)
+				}
+			}
+			m.currentView = viewMain
+			return m, nil
+		}
+		return m.submitLog()
+	}
+	var cmd tea.Cmd
+	m.quickAddInput, cmd = m.quickAddInput.Update(msg)
+	return m, cmd
+}
+
+// quickAddView shows the prompt and how the line typed so far parses.
+func (m formModel) quickAddView() string {
+	preview := ""
+	if m.quickAddInput.Value() != "" {
+		l, err := quickadd.Parse(m.quickAddInput.Value())
+		if err != nil {
+			preview = "\n" + descriptionStyle.Render(err.Error())
+		} else {
+			date := "now"
+			if !l.Date.IsZero() {
+				date = l.Date.Format(dateInputLayout)
+			}
+			preview = "\n" + summaryStyle.Render(fmt.Sprintf("%s %s | %s | %s | %s | %d mins | %s", l.Platform, l.QuestionID, l.Topic, l.Difficulty, l.Status, l.TimeSpent, date))
+			if l.Notes != "" {
+				preview += "\n" + summaryStyle.Render("Notes: "+notesSummary(l.Notes))
+			}
+		}
+	}
+	return "Quick Add:\n" + focusedStyle.Render(m.quickAddInput.View()) + preview + "\n\n" + descriptionStyle.Render(quickAddHelp)
+}
@@ -0,0 +1,72 @@
+package tui
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/Harschmann/Todo-/core"
+	"github.com/Harschmann/Todo-/db"
+	"github.com/Harschmann/Todo-/model"
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/list"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+type reviewListItem model.Review
+
+func (r reviewListItem) FilterValue() string {
+	return fmt.Sprintf("%s %s %s", r.QuestionID, r.Platform, r.Topic)
+}
+func (r reviewListItem) Title() string { return r.QuestionID }
+func (r reviewListItem) Description() string {
+	last := "never reviewed"
+	if !r.LastReviewed.IsZero() {
+		last = "last reviewed " + r.LastReviewed.Format("2006-01-02")
+	}
+	return fmt.Sprintf("%s | %s | due %s | %s", r.Platform, r.Topic, r.Due.Format("2006-01-02"), last)
+}
+
+func newReviewList(reviews []model.Review, width int) list.Model {
+	items := make([]list.Item, len(reviews))
+	for i, r := range reviews {
+		items[i] = reviewListItem(r)
+	}
+	l := list.New(items, newLogDelegate(), width, 14)
+	l.Title = "Due for Review"
+	l.SetShowStatusBar(false)
+	l.SetFilteringEnabled(false)
+	l.AdditionalShortHelpKeys = func() []key.Binding {
+		return []key.Binding{
+			key.NewBinding(key.WithKeys("0", "1", "2", "3", "4", "5"), key.WithHelp("0-5", "grade recall")),
+		}
+	}
+	return l
+}
+
+// updateReview grades the selected problem when a digit from 0 to 5 is pressed.
+func (m formModel) updateReview(msg tea.KeyMsg) (formModel, tea.Cmd, bool) {
+	if msg.String() == "tab" || msg.String() == "esc" {
+		m.currentView = viewMain
+		return m, nil, true
+	}
+	s := msg.String()
+	if len(s) != 1 || s[0] < '0'+core.MinGrade || s[0] > '0'+core.MaxGrade {
+		return m, nil, false
+	}
+	if len(m.reviewList.Items()) == 0 {
+		return m, nil, true
+	}
+	selected := model.Review(m.reviewList.SelectedItem().(reviewListItem))
+	if err := core.GradeReview(&selected, int(s[0]-'0'), time.Now()); err != nil {
+		m.errorMsg = fmt.Sprintf("Review Error: %v", err)
+		return m, clearErrorAfter(5 * time.Second), true
+	}
+	if err := db.SaveReview(&selected); err != nil {
+		m.errorMsg = fmt.Sprintf("Review Error: %v", err)
+		return m, clearErrorAfter(5 * time.Second), true
+	}
+	m.reviewList.RemoveItem(m.reviewList.Index())
+	return m, nil, true
+}
+
+const reviewHelp = "Grade your recall: 0 forgot · 1-2 barely · 3 with effort · 4 with hesitation · 5 perfect"
@@ -0,0 +1,90 @@
+package tui
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/Harschmann/Todo-/db"
+	"github.com/charmbracelet/bubbles/list"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+var matchStyle = selectedItemStyle.PaddingLeft(0).Bold(true)
+
+type searchListItem struct {
+	db.SearchResult
+	terms []string
+}
+
+func (s searchListItem) FilterValue() string { return s.Log.QuestionID }
+func (s searchListItem) Title() string {
+	return fmt.Sprintf("%s | %s | %s", s.Log.QuestionID, s.Log.Platform, s.Log.Date.Format("2006-01-02"))
+}
+func (s searchListItem) Description() string {
+	return s.Source + ": " + db.HighlightTerms(s.Snippet, s.terms, func(w string) string { return matchStyle.Render(w) })
+}
+
+func newSearchInput() textinput.Model {
+	searchInput := textinput.New()
+	searchInput.Placeholder = "e.g., segment tree"
+	searchInput.Prompt = "Search notes and solutions: "
+	searchInput.CharLimit = 100
+	return searchInput
+}
+
+func newSearchList(results []db.SearchResult, terms []string, width int) list.Model {
+	items := make([]list.Item, len(results))
+	for i, r := range results {
+		items[i] = searchListItem{SearchResult: r, terms: terms}
+	}
+	l := list.New(items, newLogDelegate(), width, 14)
+	l.Title = "Search Results"
+	l.SetShowStatusBar(false)
+	l.SetFilteringEnabled(false)
+	l.SetShowHelp(false)
+	return l
+}
+
+// updateSearch re-runs the search as the query is typed, so results are
+// always ranked for what is in the box.
+func (m formModel) updateSearch(msg tea.KeyMsg) (formModel, tea.Cmd) {
+	switch msg.String() {
+	case "tab", "esc":
+		m.searchInput.Blur()
+		m.currentView = viewMain
+		return m, nil
+	case "up", "down":
+		var cmd tea.Cmd
+		m.searchList, cmd = m.searchList.Update(msg)
+		return m, cmd
+	case "enter":
+		if selected, ok := m.searchList.SelectedItem().(searchListItem); ok {
+			return m.openLogDetails(selected.Log, viewSearch), nil
+		}
+		return m, nil
+	}
+
+	var cmd tea.Cmd
+	m.searchInput, cmd = m.searchInput.Update(msg)
+	results, err := db.Search(m.searchInput.Value())
+	if err != nil {
+		m.errorMsg = fmt.Sprintf("Search Error: %v", err)
+		return m, tea.Batch(cmd, clearErrorAfter(5*time.Second))
+	}
+	height := m.searchList.Height()
+	m.searchList = newSearchList(results, db.Terms(m.searchInput.Value()), m.logsList.Width())
+	m.searchList.SetHeight(height)
+	return m, cmd
+}
+
+func (m formModel) searchView() string {
+	view := m.searchInput.View() + "\n\n"
+	switch {
+	case len(m.searchList.Items()) > 0:
+		view += m.searchList.View()
+	case m.searchInput.Value() != "":
+		view += descriptionStyle.Render("No notes or solutions match.")
+	}
+	return view + "\n" + descriptionStyle.Render("↑/↓: choose • enter: open log • tab/esc: back to menu")
+}
@@ -0,0 +1,99 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/Harschmann/Todo-/config"
+	"github.com/Harschmann/Todo-/db"
+	"github.com/Harschmann/Todo-/model"
+	"github.com/Harschmann/Todo-/utils"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+const solutionHelp = "←/→: previous/next solution • a: add another in $EDITOR • any other key: back"
+
+// solutionEditedMsg carries a new solution back from an external editor.
+type solutionEditedMsg struct {
+	code string
+	err  error
+}
+
+// addSolution opens $EDITOR on an empty file in the configured language.
+func addSolution() tea.Cmd {
+	ext := utils.LanguageExtension(config.Get().SolutionLanguage)
+	return openEditor("", "todoplusplus-solution-*"+ext, func(text string, err error) tea.Msg {
+		return solutionEditedMsg{code: text, err: err}
+	})
+}
+
+// saveEditedSolution attaches code written in the editor to the selected log
+// and shows it.
+func (m formModel) saveEditedSolution(msg solutionEditedMsg) (formModel, tea.Cmd) {
+	if msg.err != nil {
+		m.errorMsg = fmt.Sprintf("Editor Error: %v", msg.err)
+		return m, clearErrorAfter(5 * time.Second)
+	}
+	if strings.TrimSpace(msg.code) == "" {
+		return m, nil
+	}
+	language := config.Get().SolutionLanguage
+	s := model.Solution{
+		LogDate:  m.selectedLog.Date,
+		Language: utils.LanguageForFile("solution" + utils.LanguageExtension(language)),
+		FileName: "solution" + utils.LanguageExtension(language),
+		Code:     msg.code + "\n",
+	}
+	if s.Language == "" {
+		s.Language = language
+	}
+	if err := db.SaveSolution(&s); err != nil {
+		m.errorMsg = fmt.Sprintf("Solution Error: %v", err)
+		return m, clearErrorAfter(5 * time.Second)
+	}
+	m.solutions = append(m.solutions, s)
+	m.solutionIndex = len(m.solutions) - 1
+	m.currentView = viewSolution
+	return m, nil
+}
+
+func (m formModel) updateSolution(msg tea.KeyMsg) (formModel, tea.Cmd) {
+	switch msg.String() {
+	case "left", "h":
+		if m.solutionIndex > 0 {
+			m.solutionIndex--
+		}
+	case "right", "l":
+		if m.solutionIndex < len(m.solutions)-1 {
+			m.solutionIndex++
+		}
+	case "a":
+		return m, addSolution()
+	default:
+		m.currentView = viewLogDetails
+	}
+	return m, nil
+}
+
+func (m formModel) solutionView() string {
+	s := m.solutions[m.solutionIndex]
+	header := fmt.Sprintf("--- Solution %d of %d: %s (%s) ---", m.solutionIndex+1, len(m.solutions), s.FileName, s.Language)
+	return header + "\n\n" + renderCode(s.Code, s.Language, m.logsList.Width()) + "\n\n" + descriptionStyle.Render(solutionHelp)
+}
+
+// renderCode highlights source code by rendering it as a fenced Markdown
+// block, so it picks up the same theme as the notes.
+func renderCode(code, language string, width int) string {
+	longest, run := 0, 0
+	for _, r := range code {
+		if r == '`' {
+			run++
+			longest = max(longest, run)
+		} else {
+			run = 0
+		}
+	}
+	fence := strings.Repeat("`", max(3, longest+1))
+	return renderMarkdown(fence+language+"\n"+strings.TrimRight(code, "\n")+"\n"+fence, width)
+}
@@ -0,0 +1,28 @@
+package utils
+
+import (
+	"fmt"
+	"os/exec"
+	"runtime"
+)
+
+// OpenBrowser opens url in the system's default browser without waiting for it.
+func OpenBrowser(url string) error {
+	if url == "" {
+		return fmt.Errorf("no URL to open")
+	}
+	var cmd *exec.Cmd
+	switch runtime.GOOS {
+	case "windows":
+		cmd = exec.Command("rundll32", "url.dll,FileProtocolHandler", url)
+	case "darwin":
+		cmd = exec.Command("open", url)
+	default:
+		cmd = exec.Command("xdg-open", url)
+	}
+	if err := cmd.Start(); err != nil {
+		return fmt.Errorf("could not open browser: %w", err)
+	}
+	go cmd.Wait()
+	return nil
+}
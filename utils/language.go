@@ -0,0 +1,32 @@
+package utils
+
+import (
+	"strings"
+
+	"github.com/alecthomas/chroma/v2/lexers"
+)
+
+// LanguageForFile guesses a source file's language from its name, e.g. "C++"
+// for main.cpp. It returns "" if the extension is unknown.
+func LanguageForFile(fileName string) string {
+	lexer := lexers.Match(fileName)
+	if lexer == nil {
+		return ""
+	}
+	return lexer.Config().Name
+}
+
+// LanguageExtension returns the usual file extension for a language name or
+// alias, e.g. ".py" for "python", or ".txt" if the language is unknown.
+func LanguageExtension(language string) string {
+	lexer := lexers.Get(language)
+	if lexer == nil {
+		return ".txt"
+	}
+	for _, pattern := range lexer.Config().Filenames {
+		if ext := strings.TrimPrefix(pattern, "*"); strings.HasPrefix(ext, ".") && !strings.ContainsAny(ext, "[*?") {
+			return ext
+		}
+	}
+	return ".txt"
+}
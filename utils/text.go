@@ -0,0 +1,35 @@
+package utils
+
+import (
+	"fmt"
+	"strings"
+)
+
+// SplitQuoted breaks s at unquoted whitespace, keeping the quotes in the
+// words, so `topic:"binary search" dp` is two words.
+func SplitQuoted(s string) ([]string, error) {
+	var words []string
+	var current strings.Builder
+	inQuotes := false
+	for _, r := range s {
+		switch {
+		case r == '"':
+			inQuotes = !inQuotes
+			current.WriteRune(r)
+		case !inQuotes && (r == ' ' || r == '\t' || r == '\n'):
+			if current.Len() > 0 {
+				words = append(words, current.String())
+				current.Reset()
+			}
+		default:
+			current.WriteRune(r)
+		}
+	}
+	if inQuotes {
+		return nil, fmt.Errorf("unclosed quote in %q", s)
+	}
+	if current.Len() > 0 {
+		words = append(words, current.String())
+	}
+	return words, nil
+}
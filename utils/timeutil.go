@@ -1 +1,135 @@
-package utils
\ No newline at end of file
+package utils
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var dateLayouts = []string{"2006-01-02 15:04", "2006-01-02T15:04", "2006-01-02"}
+
+var (
+	offsetPattern   = regexp.MustCompile(`^([+-]\d+)([mhdw])$`)
+	clockPattern    = regexp.MustCompile(`^(\d{1,2}):(\d{2})$`)
+	durationPattern = regexp.MustCompile(`^(?:(\d+)h)?(?:(\d+)m(?:ins?)?)?$`)
+)
+
+// ParseDuration parses a time spent such as "45m", "1h", "1h30m" or "90min"
+// into minutes. Bare numbers are not durations.
+func ParseDuration(s string) (int, bool) {
+	m := durationPattern.FindStringSubmatch(strings.ToLower(s))
+	if m == nil || s == "" {
+		return 0, false
+	}
+	hours, _ := strconv.Atoi(m[1])
+	mins, _ := strconv.Atoi(m[2])
+	return 60*hours + mins, true
+}
+
+var dayWords = map[string]int{"today": 0, "yesterday": -1, "tomorrow": 1}
+
+// StartOfDay returns midnight at the start of t's day, in t's location.
+func StartOfDay(t time.Time) time.Time {
+	year, month, day := t.Date()
+	return time.Date(year, month, day, 0, 0, 0, 0, t.Location())
+}
+
+// StartOfWeek returns the start of the week containing t. Weeks start on
+// Monday everywhere: goals, summaries and reports.
+func StartOfWeek(t time.Time) time.Time {
+	start := StartOfDay(t)
+	return start.AddDate(0, 0, -((int(start.Weekday()) + 6) % 7))
+}
+
+// ParseDate parses a date such as "2026-01-31" or "2026-01-31 20:35" in local
+// time. It also accepts "now", "today", "yesterday" and offsets from now such
+// as "-2d", "-3h" or "-1w", optionally followed by a time ("yesterday 21:30"),
+// and a bare time ("21:30") for today. Without a time, including for offsets,
+// the result is the start of the day.
+func ParseDate(s string) (time.Time, error) {
+	t, hasTime, err := parseDate(s, time.Now())
+	if err != nil || hasTime {
+		return t, err
+	}
+	return StartOfDay(t), nil
+}
+
+// ParseLogDate parses a log's date like ParseDate, except that a date without
+// a time keeps the time of day it falls on: "yesterday" and "2026-01-31"
+// get the current time of day and "-3h" is three hours ago. An empty string
+// means now. Dates in the future are rejected.
+func ParseLogDate(s string) (time.Time, error) {
+	now := time.Now()
+	if strings.TrimSpace(s) == "" {
+		return now, nil
+	}
+	t, _, err := parseDate(s, now)
+	if err != nil {
+		return t, err
+	}
+	if t.After(now) {
+		return time.Time{}, fmt.Errorf("%s is in the future", t.Format("2006-01-02 15:04"))
+	}
+	return t, nil
+}
+
+// parseDate parses s relative to now, and reports whether s had a time of day
+// in it. Dates without one keep now's time of day.
+func parseDate(s string, now time.Time) (time.Time, bool, error) {
+	s = strings.TrimSpace(s)
+	now = now.In(time.Local)
+	for _, layout := range dateLayouts {
+		if t, err := time.ParseInLocation(layout, s, time.Local); err == nil {
+			if layout == "2006-01-02" {
+				return time.Date(t.Year(), t.Month(), t.Day(), now.Hour(), now.Minute(), now.Second(), now.Nanosecond(), time.Local), false, nil
+			}
+			return t, true, nil
+		}
+	}
+
+	invalid := fmt.Errorf("invalid date %q (want YYYY-MM-DD, YYYY-MM-DD HH:MM, today, yesterday or an offset like -2d)", s)
+	words := strings.Fields(strings.ToLower(s))
+	if len(words) == 0 || len(words) > 2 {
+		return time.Time{}, false, invalid
+	}
+	if len(words) == 1 && clockPattern.MatchString(words[0]) {
+		words = []string{"today", words[0]}
+	}
+
+	var t time.Time
+	if n, ok := dayWords[words[0]]; ok {
+		t = now.AddDate(0, 0, n)
+	} else if words[0] == "now" && len(words) == 1 {
+		t = now
+	} else if m := offsetPattern.FindStringSubmatch(words[0]); m != nil {
+		n, _ := strconv.Atoi(m[1])
+		switch m[2] {
+		case "m":
+			t = now.Add(time.Duration(n) * time.Minute)
+		case "h":
+			t = now.Add(time.Duration(n) * time.Hour)
+		case "d":
+			t = now.AddDate(0, 0, n)
+		case "w":
+			t = now.AddDate(0, 0, 7*n)
+		}
+	} else {
+		return time.Time{}, false, invalid
+	}
+
+	if len(words) == 1 {
+		return t, false, nil
+	}
+	m := clockPattern.FindStringSubmatch(words[1])
+	if m == nil {
+		return time.Time{}, false, invalid
+	}
+	hour, _ := strconv.Atoi(m[1])
+	minute, _ := strconv.Atoi(m[2])
+	if hour > 23 || minute > 59 {
+		return time.Time{}, false, invalid
+	}
+	return time.Date(t.Year(), t.Month(), t.Day(), hour, minute, 0, 0, time.Local), true, nil
+}
@@ -0,0 +1,132 @@
+package utils
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseDuration(t *testing.T) {
+	tests := []struct {
+		in   string
+		want int
+		ok   bool
+	}{
+		{"45m", 45, true},
+		{"1h", 60, true},
+		{"1h30m", 90, true},
+		{"90min", 90, true},
+		{"2mins", 2, true},
+		{"1H", 60, true},
+		{"45", 0, false},
+		{"", 0, false},
+		{"h", 0, false},
+		{"1d", 0, false},
+	}
+	for _, tt := range tests {
+		got, ok := ParseDuration(tt.in)
+		if got != tt.want || ok != tt.ok {
+			t.Errorf("ParseDuration(%q) = %d, %v, want %d, %v", tt.in, got, ok, tt.want, tt.ok)
+		}
+	}
+}
+
+func TestParseDateRelative(t *testing.T) {
+	now := time.Date(2026, 3, 15, 20, 45, 30, 0, time.Local)
+	at := func(day, hour, minute, second int) time.Time {
+		return time.Date(2026, 3, day, hour, minute, second, 0, time.Local)
+	}
+	tests := []struct {
+		in      string
+		want    time.Time
+		hasTime bool
+	}{
+		// Without a time, the day keeps now's time of day.
+		{"2026-03-01", at(1, 20, 45, 30), false},
+		{"today", now, false},
+		{"yesterday", at(14, 20, 45, 30), false},
+		{"now", now, false},
+		{"-2d", at(13, 20, 45, 30), false},
+		{"-1w", at(8, 20, 45, 30), false},
+		{"-3h", at(15, 17, 45, 30), false},
+		{"-30m", at(15, 20, 15, 30), false},
+		// With one, it is used as given.
+		{"2026-03-01 09:30", at(1, 9, 30, 0), true},
+		{"2026-03-01T09:30", at(1, 9, 30, 0), true},
+		{"yesterday 21:30", at(14, 21, 30, 0), true},
+		{"-2d 8:05", at(13, 8, 5, 0), true},
+		{"07:15", at(15, 7, 15, 0), true},
+		{"  Yesterday  ", at(14, 20, 45, 30), false},
+	}
+	for _, tt := range tests {
+		got, hasTime, err := parseDate(tt.in, now)
+		if err != nil {
+			t.Errorf("parseDate(%q): %v", tt.in, err)
+			continue
+		}
+		if !got.Equal(tt.want) || hasTime != tt.hasTime {
+			t.Errorf("parseDate(%q) = %v, %v, want %v, %v", tt.in, got, hasTime, tt.want, tt.hasTime)
+		}
+	}
+}
+
+func TestParseDateErrors(t *testing.T) {
+	for _, in := range []string{"", "soon", "2026-13-01", "yesterday noon", "today 25:00", "now 10:00", "-2y", "a b c"} {
+		if _, _, err := parseDate(in, time.Now()); err == nil {
+			t.Errorf("parseDate(%q) succeeded, want an error", in)
+		}
+	}
+}
+
+func TestParseDateStartsTheDay(t *testing.T) {
+	for _, in := range []string{"2026-03-01", "yesterday", "-2d"} {
+		got, err := ParseDate(in)
+		if err != nil {
+			t.Errorf("ParseDate(%q): %v", in, err)
+			continue
+		}
+		if !got.Equal(StartOfDay(got)) {
+			t.Errorf("ParseDate(%q) = %v, want the start of the day", in, got)
+		}
+	}
+	got, err := ParseDate("2026-03-01 09:30")
+	if err != nil || got.Hour() != 9 || got.Minute() != 30 {
+		t.Errorf("ParseDate(2026-03-01 09:30) = %v, %v, want 09:30", got, err)
+	}
+}
+
+func TestParseLogDate(t *testing.T) {
+	before := time.Now()
+	got, err := ParseLogDate("")
+	if err != nil || got.Before(before) || got.After(time.Now()) {
+		t.Errorf("ParseLogDate(\"\") = %v, %v, want now", got, err)
+	}
+
+	got, err = ParseLogDate("yesterday")
+	if err != nil {
+		t.Fatalf("ParseLogDate(yesterday): %v", err)
+	}
+	if want := time.Now().AddDate(0, 0, -1); got.Sub(want).Abs() > time.Minute {
+		t.Errorf("ParseLogDate(yesterday) = %v, want about %v", got, want)
+	}
+
+	for _, in := range []string{"tomorrow", "+1h", "+2d 10:00", time.Now().AddDate(1, 0, 0).Format("2006-01-02")} {
+		_, err := ParseLogDate(in)
+		if err == nil || !strings.Contains(err.Error(), "in the future") {
+			t.Errorf("ParseLogDate(%q) = %v, want a future date error", in, err)
+		}
+	}
+}
+
+func TestStartOfWeek(t *testing.T) {
+	monday := time.Date(2026, 3, 9, 0, 0, 0, 0, time.Local)
+	for day := 9; day <= 15; day++ {
+		in := time.Date(2026, 3, day, 23, 59, 0, 0, time.Local)
+		if got := StartOfWeek(in); !got.Equal(monday) {
+			t.Errorf("StartOfWeek(%s) = %v, want %v", in.Format("Mon 2006-01-02"), got, monday)
+		}
+	}
+	if got := StartOfWeek(monday.AddDate(0, 0, 7)); !got.Equal(monday.AddDate(0, 0, 7)) {
+		t.Errorf("StartOfWeek of a Monday = %v, want that Monday", got)
+	}
+}